@@ -79,7 +79,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _readmeMd = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x96\x5f\x6f\xdb\x36\x14\xc5\xdf\xf9\x29\x0e\x9c\x3e\x6c\x40\x69\xd9\x8e\x17\xc7\x01\x0a\x2c\xed\xba\xad\x1b\xb6\x06\x6d\x3a\x6c\x6f\xa2\xc8\x2b\x8b\xb0\x44\x0a\x24\x15\xd7\x98\xbb\xcf\x3e\x90\x52\xec\x65\x73\xff\x25\x28\x30\x3d\x04\x97\xd6\xe5\xbd\x87\xbf\x73\x6d\xe6\x04\x57\xba\xa5\x5a\x1b\xba\xc0\x65\xa8\x85\x01\xc7\x53\x21\xd7\x64\x14\x9e\x9b\x95\x36\x44\x4e\x9b\x15\x5e\x98\x40\x2e\xbe\xbc\x16\x7e\xcd\xd8\xf3\xb7\x2d\x39\xdd\x90\x09\xf1\xe5\x46\x87\x0a\xad\xe8\x3c\x89\xa2\xa6\xc7\x70\xe4\xbb\x26\x86\x08\xc2\xaf\x3d\xb4\x81\xc0\x86\x0a\x78\x72\x37\x5a\xd2\x98\xb1\x93\x13\xbc\xf1\x62\x45\x31\x8a\x61\x2c\xf3\x9d\x95\x6b\x72\x8c\xbd\xea\x0c\x72\x95\x16\x70\x9d\x01\xd7\x01\xbc\xc5\xf9\xe4\x7c\x72\x11\xff\xa0\x75\x8d\x77\x7e\x13\xb2\x76\xd0\x9e\x8f\x71\x5d\x11\x2e\xaf\x5e\x60\xa3\xeb\x1a\x05\x41\x48\x49\xde\xeb\x28\xc2\x1a\xe4\x55\x08\xed\x45\x96\xd5\x56\x8a\xba\xb2\x3e\xa4\x42\x79\x12\x72\x72\x82\xa7\x9d\xae\x55\x94\xa0\x1b\xb1\x22\x6c\x6d\xe7\x3c\xd5\x25\x63\xbc\x7f\x85\x50\xd1\xf0\xae\x4b\x52\xe3\xba\x75\xf6\x46\x2b\x52\x83\xee\x52\xd7\xf1\x60\x40\x9e\xe7\x0c\x18\xf4\x17\x69\x3b\x0f\xb8\x95\x8a\xf1\x90\xc2\x38\x7e\xb5\x9b\xd8\x0b\x52\x98\x74\x50\x1d\x86\xf2\x7d\xc5\xff\x56\x3b\x4e\x63\xa8\x7c\xa8\xfb\xc7\x50\xd3\xd8\xcd\xc0\x21\x09\x8e\x78\x3e\xc6\xe2\x80\xa2\x74\xb6\x81\xb7\x9d\x93\x14\x6b\xfe\xd4\xf9\x90\xfa\xe7\x2b\x8b\x15\x05\xac\x74\xa8\xba\x62\x2c\x6d\x93\x1d\xf1\x23\x6e\x89\x96\x14\xda\x08\xb7\xed\x5d\x89\x72\xa2\x33\x37\x42\xd7\x69\x3a\xb4\xf1\x5a\xf5\xb8\x91\x3f\xfa\xe1\xe5\xd5\xe5\xf5\x8f\x59\xa1\x4d\x8e\xaf\xf2\xbf\xb2\x95\xed\x63\x6d\xd0\x58\x1f\x20\x85\x27\xff\xf5\x78\x7f\x3a\xaf\x9b\xb6\xde\xde\x05\xb7\xdf\x76\x47\x4a\x3c\xd7\xcf\x5d\x41\xce\x50\x20\xcf\xd8\x6d\x85\x52\x1b\x05\x7a\x2b\x9a\xb6\x26\x34\xc2\xe8\x92\x7c\x48\xe3\x1a\x71\xe5\xfb\x4f\xb2\x1c\x4a\x3b\x92\xc1\xba\xed\x18\xbf\x58\xa5\xcb\x6d\x4c\x69\x22\x5d\xeb\x12\xae\x60\xfb\x73\x18\x22\xe5\x21\x8c\x82\xa2\xb6\xb6\xdb\x5b\x61\xeb\xae\x20\x19\x6a\x48\x47\x22\x10\x78\x89\x71\xb6\x6f\xd0\x8b\x4c\x06\x39\x2a\xc9\x91\x91\x34\x8c\x66\x9e\x75\x6d\x6d\x85\xca\xc1\xf1\x26\x45\x78\xf6\xfa\x37\xc4\x69\x63\x6c\x07\x6d\xda\x2e\x00\x3b\x28\xf2\xd2\xe9\x36\x68\x6b\xf0\xbe\x67\xc7\x76\xe0\xe9\xc1\x6d\xf0\xc1\x27\x6d\xc8\x63\xab\x1c\x3b\x5c\xee\x1b\x63\x53\x69\x59\xf5\xa6\xc6\x51\x68\x9d\x8d\x43\x46\x0a\x3b\xc6\xf2\x3c\x2f\x84\xaf\xd8\x23\xc8\xce\xd5\xe0\xbf\xe3\xea\xe5\xeb\x6b\xf0\xef\x31\x8a\x7b\x9f\x7c\xdb\x8a\x50\x65\xc1\x66\x81\x7c\x18\x4b\x7f\x33\xc2\xd1\x81\x1c\xce\xcd\xd8\x9f\x0c\x18\xf9\x20\x42\xe7\x47\x17\x18\xf9\x2e\x4d\xf4\xe8\x71\xfc\x58\x89\x20\x46\x17\x88\x29\xc0\x48\xab\x98\x50\xd0\xf2\xf4\x6c\x21\x4f\xb9\x9c\x2f\x67\x7c\x2e\x69\xc1\xc5\xec\x9b\x33\x2e\xcb\x79\x39\x9b\x0a\xb1\x28\x4e\xe7\x23\x06\xbc\x63\xef\x58\xfa\xc2\x0c\x9c\xfb\x16\x91\xf3\xb3\x8a\xe4\x1a\xfd\x1a\xb6\x84\x48\xbf\x63\x07\xdc\x9f\x46\xfb\xdf\xd0\x3f\x8d\xf9\x1d\xf4\x5a\xe5\xd1\xdb\xf8\x45\x8a\x0a\xa0\x55\x94\x13\x6e\x97\xf1\xb7\x63\x23\x4c\xf8\x87\xd4\x0f\x1b\xa0\xd5\x93\x99\x5c\x9c\xd3\xe2\x6c\xc2\xa7\x72\xa2\xf8\x7c\x3a\x27\xbe\x5c\x8a\x39\x3f\x2d\xc4\x6c\x51\x2c\xce\xe4\xa4\x9c\xbc\xcf\x91\xbe\xcd\xe7\x38\x72\x48\x72\x9d\x31\xda\xac\x8e\x81\x4f\x37\x47\xe4\x7e\x15\x03\x08\x0c\xb9\x0f\xa0\x7e\x2f\xe8\x9f\xc7\x3c\xd8\xfe\xca\xfb\x38\x72\x52\x85\x98\x4e\xcf\x0b\x3e\x39\x55\x05\x9f\x17\x45\xc9\xc5\x72\x2e\xf9\x62\x52\x4e\x97\xcb\x59\x59\xce\xcb\xe9\xfb\x90\xa7\x16\x9f\x43\xbc\x21\x1f\xef\xd5\x98\x95\xf4\xa6\x02\xea\x18\xf6\x74\x4b\x27\xee\xaf\x52\x04\x31\x24\xff\xdf\xa7\x3d\xd8\xfe\x3f\x8c\x2f\x8c\xbe\xef\xf1\x10\xf6\x7d\x85\xa3\xf0\x03\xb9\x46\x1b\x11\x12\xff\xfd\xe2\x30\xfb\xd9\xc3\xad\xb8\xb7\x1b\xf7\x31\xe4\x70\x84\x2f\xea\xc9\xbe\xcd\x43\x6c\xd9\x17\xb9\xe3\xcc\xdf\x01\x00\x00\xff\xff\x78\x02\xbd\xf3\x0a\x0b\x00\x00")
+var _readmeMd = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xd4\x7d\xfd\x93\xdb\x36\x96\xe0\xef\xfa\x2b\xde\xca\x5b\x3b\xf6\x15\xa9\x96\xda\xdd\xb1\xbb\xb7\x5c\xbb\x4e\xe2\xd9\x24\x97\x8c\x7d\xb6\xe7\x66\x6f\x1d\x57\x11\x22\xa1\x16\xa6\x49\x82\x01\xc0\x96\x35\xb1\xef\x6f\xbf\x7a\xef\x01\x20\x28\xa9\xdb\x1f\xdd\x9e\xb9\xf5\x4c\xc5\x14\x08\x02\x0f\xef\x0b\xef\x0b\xf0\x3d\x28\x5e\xa8\x4e\xd6\xaa\x95\xc5\x64\xf2\xec\x5d\x27\x8d\x6a\x64\xeb\x54\x7b\x01\x1b\xe5\xd6\xd0\x89\xde\x4a\xb1\xac\x65\x06\x46\xda\xbe\xc1\x47\x70\xc2\x5e\x5a\x50\x2d\x08\xd8\xc8\x25\x58\x69\xae\x54\x29\x67\x93\xc9\xbd\x7b\xf0\x67\x2b\x2e\x24\x3e\xe1\x23\x0e\xf3\xbd\x2e\x2f\xa5\x99\x4c\x5e\xf6\x2d\x14\x15\xfd\x00\xd3\xb7\x90\x2b\x07\x79\x07\x8f\xe7\x8f\xe7\xe7\xf8\x1f\xe8\x4c\x63\x8d\xdd\xb8\xa3\x2e\x40\x34\x83\xd7\x6b\x09\x4f\x5f\xfc\x08\x1b\x55\xd7\xb0\x94\x20\xca\x52\x5a\xab\x10\x08\xdd\x42\xb1\x76\xae\x3b\x3f\x3a\xaa\x75\x29\xea\xb5\xb6\x8e\x06\x2a\x08\x90\x7b\xf7\xe0\xdb\x5e\xd5\x15\x82\xa0\x1a\x71\x21\x61\xab\x7b\x63\x65\xbd\x9a\x4c\x72\x7e\x05\x6e\x2d\xfd\xbb\x9e\x40\xc5\xdf\x9d\xd1\x57\xaa\x92\x95\x87\x7b\xa5\x6a\x5c\x18\x40\x51\x14\x13\x00\x0f\xff\x92\x3e\xcf\x1d\x04\x50\x61\xe6\xbb\x4c\x72\xf8\x93\xde\xe0\x5c\x50\x8a\x96\x16\xaa\x9c\x1f\x9e\x47\xdc\x1f\xed\x30\x36\xfc\xc8\xc3\xb8\xff\xc7\x8f\xd9\xea\x8d\xc7\x03\x38\x8f\x9e\x8f\xe1\x62\x40\xc5\xca\xe8\x06\xac\xee\x4d\x29\x71\xcc\x9f\x7a\xeb\x68\xfe\xe2\x42\xc3\x85\x74\x70\xa1\xdc\xba\x5f\xce\x4a\xdd\x1c\x1d\xa0\x07\x7e\x82\x24\x59\xaa\x56\x98\x2d\x53\x05\xc1\x41\xca\x5c\x09\x55\x13\x77\xa8\xd6\xaa\x8a\xd1\x0d\xc5\x3f\xff\xc7\xf3\x17\x4f\x5f\xff\x70\xb4\x54\x6d\x01\xf7\x8b\xff\x7b\x74\xa1\xf9\x59\xb5\xd0\x68\xeb\xa0\x14\x56\xda\x07\xb3\xb8\x3a\xab\x9a\xae\xde\x8e\x11\x17\x3f\x1b\x81\x82\xeb\xfa\x9f\xfd\x52\x9a\x56\x3a\x69\x27\x93\x30\xc2\x4a\xb5\x15\xc8\x77\xa2\xe9\x6a\x09\x8d\x68\xd5\x4a\x5a\x47\xec\x8a\xe8\x2a\x62\xcb\x51\x01\x95\x32\xb2\x74\xda\x6c\x67\xf0\x8b\xae\xd4\x6a\x8b\x5d\x1a\xc4\xae\x36\x84\x2e\xa7\x79\x1d\xad\x94\x95\x05\xd1\x56\x50\xc9\xae\xd6\xdb\x00\xd8\x65\xbf\x94\xa5\xab\xa1\x34\x52\x38\x09\xf9\x0a\x66\x47\x71\x82\x00\xe4\x77\x6b\x59\x5e\x76\x5a\xb5\xce\x4e\x26\xaf\x49\x76\xac\xb8\x92\x38\x97\x32\xc8\x70\x17\x46\x5a\x0b\xad\x7c\xe7\x70\x42\x84\xb2\xef\x6a\x2d\x90\x0b\x91\xff\x22\xe8\xdc\x3a\x02\x1c\x36\x6b\xd9\xca\x2b\x69\xb0\xc7\x96\x48\x48\x22\x5b\x11\xb0\xf8\x62\x0b\x8b\x39\x58\x59\xea\xb6\xb2\xb0\x59\xe3\x78\xa6\x6f\x5b\x04\xff\x7e\xa9\xdb\x95\xba\xe8\x0d\xd1\x6d\x90\x81\x22\x2f\x23\xc8\xb9\x6a\x9d\x34\x57\xa2\x2e\x60\x55\x8b\x8b\x07\x33\x78\xde\x82\x75\xc2\xb8\xbe\xcb\xe2\x48\xac\x11\x4a\x8d\x9a\xa3\x97\xcc\x65\xbc\xbc\x5a\x20\x91\xe3\x70\x04\x96\x87\x90\x3f\xb2\x4e\x6c\x7d\x4b\x06\x56\xc3\xa5\x94\xdd\xf5\xcb\x15\xa5\xd1\xd6\x82\x91\x04\x82\x85\xfb\x72\x76\x31\x83\x46\xf7\x38\x34\x5c\xe9\xba\x6f\x24\x08\x07\xc5\x91\xe8\xba\x23\x3f\x42\x41\x58\x1a\x49\xe1\x03\x4f\x1b\x24\x07\x58\xa7\x8d\xf4\xa4\xc9\x40\xd4\x3a\x68\x3f\x5e\x42\xc4\x92\x53\xba\xa5\x05\xac\x15\x7e\xb2\xcd\x40\x18\x09\x97\xb2\x73\xa4\x0c\x5b\x90\xcd\x52\x56\x48\xb6\x37\xcb\xa5\xae\xdd\xdb\xfb\x28\x94\xf6\xfc\xe8\x28\x11\x2b\xe9\xca\x2a\x57\xfa\x88\x7a\x3c\x80\x4a\x38\xb1\x14\x96\x81\x0e\x2b\x0e\x6c\x3e\xab\x96\xc5\x0d\x54\xaa\x96\x4c\x94\x8c\xe7\xee\x1c\x22\xd2\xad\x09\x85\x96\x59\x19\xc5\x4c\x36\x88\x39\xdd\xd6\xdb\x07\x84\x61\xb7\x16\x0e\xa5\x44\xd9\xb5\xe7\x93\x95\x50\x75\x24\x08\xae\xc9\x3a\x14\xed\x5a\x59\x87\x3d\x56\x4e\x1a\x10\x01\xe9\xac\x95\x23\xdc\xb6\x5c\xcb\x46\x80\xb2\xd0\xa8\x0b\x23\xe8\x83\xde\xe9\x46\x38\x55\x8a\xba\xc6\x89\x07\x7e\x11\xc3\x77\x1b\xa3\x9c\x93\x2d\x2c\xb7\x20\xa0\x95\x1b\x69\xe0\x4a\x1a\x8b\x28\x56\x48\xe0\x15\x72\x44\x90\x20\xdd\x96\xbd\x31\xb2\x2d\xb7\x93\xc9\x53\xc7\x9a\x63\x31\xf7\x00\xa3\xae\x10\x0e\x74\x5b\xca\x9b\x58\x7a\x18\x23\x60\xad\x98\x17\xd0\x48\xd1\x5a\x68\x35\xd4\xaa\x51\xee\xc1\x0c\xfe\xd8\x1b\xb7\x96\xc6\x8b\x20\xa3\xa3\xf8\xad\x97\xbd\xac\x0a\x42\x16\x2d\x06\x54\xeb\x7b\x80\x36\x15\xa2\xc7\xee\x08\x83\x75\xba\x9b\xc1\x8b\x94\xd5\x03\x6b\x2b\x03\xb6\xd6\x2e\x83\xbe\xad\x83\x1a\x2f\x72\x23\x6b\x29\xac\xcc\x59\x16\x18\x46\x50\x16\xac\x74\x19\x4e\xb7\x59\xab\x72\x4d\xfa\x72\x90\x75\x86\x0b\xc4\x85\xa0\x0e\xb2\xe5\x5d\xda\x23\x8e\xf6\x06\x23\x57\x12\x57\x2d\x27\x93\x67\x6d\xc5\x6a\x28\x8c\xb5\x16\xed\x05\x8d\x86\x8b\x72\xbd\x05\xbd\x02\x41\xc0\xc2\xfd\xc2\x4b\x4f\x91\x41\x71\x44\x6b\xa6\x27\x82\x8e\x9e\x78\x26\xff\x5a\x76\x8c\x9c\xe2\xc8\x49\xd3\xa8\x56\x38\x59\x3c\x00\x51\x5b\x4d\x7b\x55\xe7\x40\x77\x28\x3e\xa2\x86\x42\xa0\x28\xfb\xee\x46\x0a\xab\x69\x3b\xe8\x7a\x67\x33\x0f\x18\xe2\xdc\x48\x54\xc2\xb2\x0a\xda\xef\x8d\x17\xba\xb7\xf7\xef\x11\x36\x55\x25\xaf\x64\xeb\x6c\x9e\xe7\xfe\x4d\xae\x57\xb9\xc8\xf1\xe5\x03\x5c\x08\x7e\x84\x3f\x98\x5f\x69\x52\xa8\xe4\x4a\xf4\xb5\xb3\x41\xcf\x8a\xaa\x22\xdd\xeb\xbb\x97\xb5\x92\xad\x0b\xf6\x43\xc4\x00\xe4\xf0\x67\x7a\x82\xef\x5e\xfd\x6f\x52\xc9\x93\xc9\x7b\x06\x19\x46\x7f\xde\x43\x25\x6d\x69\x14\x2d\x15\xbe\xfa\x9f\xf7\x93\xf7\x90\xef\xfd\x81\x43\x8d\x5f\xef\x0f\x41\x51\x20\x52\x8a\x1d\x5c\x3c\x8d\xe8\xf2\x64\x25\x7b\x81\xb6\x28\xa3\xd1\x7e\x91\xd5\xdd\xe2\xa2\xf0\xe3\x22\x77\x85\x66\xf8\x93\x68\x64\xa0\x6f\x7c\x0f\x4e\xc3\x5a\xb4\x55\x1d\xf8\xcc\x66\x50\x58\xd5\xf4\x35\x32\x2e\xdc\xf7\x7c\xf2\xe0\x8b\xa0\x70\xaa\x91\xba\x77\x09\x3a\xde\xc3\xf3\xc0\xfd\xf8\x92\x75\x0d\x94\xb8\x6b\xc9\x8a\x77\x4b\x12\xde\xc0\xb2\xac\x63\x6c\x06\xb4\xbb\x15\x67\x73\x5b\x80\x36\x50\x1c\xaf\x8b\x4f\x86\xa2\x92\xa2\x22\x53\xe9\x5a\x28\x96\x5b\x4f\x97\x38\x6d\xd3\x5b\x07\x4b\x09\x95\x6e\x65\x98\xfc\x78\x7e\x3c\xcf\xe7\x67\xf9\x7c\xf1\x7a\x71\x7a\x3e\x3f\x39\x9f\x9f\xfe\xd7\xa7\x43\xa1\x7b\xd7\x8d\x50\x01\xef\xe1\x8f\xda\x34\xc2\x05\x9a\xbc\xe1\x2e\xc4\x27\x83\x6c\x73\x63\x9e\xe7\x95\xde\xb4\x28\x7a\xb9\x5b\xcb\x9c\x5b\x1f\x64\x50\x94\xf6\x2a\x25\x13\x22\xe7\xaf\x56\xb7\x75\x71\x0d\x2e\x08\xe3\x32\xe5\x8b\x3f\x2a\x59\x57\x10\xdf\x64\x50\x64\xc9\x88\x19\xf4\x56\x42\xf1\xab\x2b\x60\x85\xec\x22\x96\xb9\x95\x9d\xe0\xfd\x0d\x41\xb5\x9f\xcf\x17\xa5\x6e\xd0\xb7\x3a\xcc\x17\xe5\x5a\x18\x51\x3a\x69\x98\xf6\xb8\x8f\xf8\xfe\x80\x54\x8c\xbc\x70\xaf\xb8\xa5\x8c\xd4\xe2\x6f\xdb\xff\xd5\x6b\x27\x6d\x31\x48\x6a\x5d\xeb\x0d\xfc\x46\xad\xb4\xb3\xb5\xf4\x8c\x2b\x95\xb5\x37\x7c\xfb\x56\xda\x52\x74\xb2\x4a\xfa\xf9\x5e\x9a\xe0\x2b\x56\xa2\xb6\x9f\x20\x3c\x2c\x23\x46\x35\x3f\x4b\x81\x46\xf6\xab\x4e\x94\xb2\x80\xf7\xf0\xe3\x45\xab\x8d\x84\x9a\x9b\x91\x37\x9d\x04\x8b\x6f\x41\xaf\x3c\x28\x9f\x3e\xcd\xa7\xe0\x82\xc7\x7c\x21\xcd\x4b\x52\x02\x05\xe9\x8b\xbe\x59\x4a\x33\xcc\xe8\x8d\x68\x56\x13\x2c\x21\x6b\x71\x25\xd9\x7a\x18\x80\x40\x2e\x11\x16\xfd\x8d\x2d\xfe\x8d\x9c\xbd\x52\xc6\x3a\xff\x61\x06\xad\xbc\x10\x4e\x5d\x49\xee\xd9\x6e\x07\x28\xd6\x52\x54\x09\x6b\x62\x33\xfc\x65\x2d\xc9\x0a\xd9\x1d\x07\xd6\x1a\x61\xc2\xe6\x12\x8d\xdd\x16\x5a\xd1\xc8\x5b\xa2\x85\xa0\x68\x44\xbd\xd2\xa6\x91\x55\x91\x42\x21\x1c\x38\x0d\x95\x66\x7b\xd8\xeb\xca\x68\x8a\xb4\x7f\x20\x75\xd1\x09\x43\xd6\x7b\x81\x76\xe4\x48\x88\x0a\x7b\xa9\x3a\xd6\x5d\xbf\xf5\xc2\x88\xd6\x8d\x34\xd2\x3e\x14\x46\x3a\xb3\x7d\xea\x1c\x5a\xb3\xcc\xa0\x29\x45\x50\x6d\x59\x10\x01\x17\x38\x5d\x8c\x54\x60\xab\x33\x5b\xb2\xfb\xa4\x31\xda\x80\xb2\xc9\x46\x23\xd8\x6a\x3c\x44\xb6\x56\xd3\xa7\x4a\xda\x11\x14\xdf\x8a\xf2\x52\xaf\x56\x45\xc0\x85\x50\xb8\xd8\x15\xb2\x68\x4a\x15\x87\x7e\x40\xa5\xfb\x65\x8d\xf2\xa2\x8d\xe7\x97\x95\x46\x99\x42\xe8\x48\x97\x16\x8b\xf9\xbc\xb1\x9f\x4c\x9e\x01\x8a\x5f\xc4\xbb\x01\x90\x54\x5f\x88\x0e\x34\xef\x18\x1b\x86\xcc\x6d\xa4\x6c\xc1\x6d\x34\x08\x8f\xbf\xa0\x33\x16\x73\x5b\xdc\x42\x5f\x2c\x65\x6d\xc7\xdc\x39\x40\xa1\x9b\x46\xc0\xa0\x19\x8b\x4b\xb9\x7d\x72\x25\xea\x5e\x16\xd0\x09\x65\x2c\x38\xcd\x0e\x79\xdc\x63\x96\xdb\x00\x96\x93\xa2\x79\xb2\x54\x35\xd2\x30\x93\xed\xd5\x93\xce\xe8\xaa\x38\x0c\x05\x51\xf4\xdb\xbe\xba\x90\xae\xd8\x83\xa2\x93\xa6\x94\xad\x13\x17\x71\xa3\x1f\x33\x6a\x23\xb6\xb0\x94\x80\xbc\x88\xfa\x4b\x1b\x18\x98\xb1\x4a\x69\x4a\x00\x5e\x48\x67\xa3\x43\xca\x90\x9e\x16\x0c\x05\xa9\xe6\x97\x7a\x73\x70\x4f\x1d\x89\xa9\xd3\x81\xf7\xb2\xf1\x3e\xbf\x20\x6e\xd5\xbd\xa3\x09\x59\xf4\x3f\x93\x22\xb2\xad\x46\x30\x8c\xa0\xa8\xc5\x21\x20\xee\xde\xee\x64\x5c\x3c\x5f\xad\xec\x21\x8a\x2c\xb7\x0e\x69\x81\x2f\x03\x49\xae\x45\x0f\xa1\x18\x7b\x14\xda\x8f\xa6\x57\x20\x5a\x2f\xc4\x1f\xc7\xc5\x08\x86\x6b\xa1\xf0\x54\x1e\x0c\x9e\xc0\x22\x03\x28\xde\xb3\xfb\x02\x5c\x2c\x8d\x14\x1c\xdf\x28\xf6\xa1\x30\x7a\x43\x93\x20\x47\x0d\x5c\x26\x5c\x46\x6a\x91\x36\x99\x20\x32\xb1\x9b\x72\x20\xdc\x08\xcc\x46\xb8\x72\x4d\xc1\x4c\x97\x05\x86\x36\xb2\x93\x24\x76\x09\x45\x46\xf2\x93\xec\x23\xda\xaf\x2e\x02\x60\xd4\xc5\xda\x81\xd8\x88\x6d\x06\x85\x33\xfd\xed\xb7\xd4\x04\x8a\xa7\x37\x59\xbf\xfb\xb0\x08\xe7\xd9\x20\xb5\x36\xe7\xc7\xe7\xf3\xf9\xf9\x7c\xfe\x5f\xc5\xe7\x42\xe1\x83\x6c\x31\x88\x46\xfb\x03\x03\xf6\xc4\x6f\x93\xa4\x33\x43\x5c\xad\xd5\x2e\xa7\xb7\xb2\x2a\x82\x27\xdc\xb7\x4e\xd5\xec\x68\x0b\x23\xe1\x8d\x7f\xff\xf6\xfe\x3d\x7a\xca\x73\xfe\x22\x17\xf8\xf7\x85\xac\xd8\xef\xcc\xc8\x54\x72\x34\xbd\xdf\x95\x06\x84\xd0\xe2\x2f\xa4\x03\x3f\x16\x93\x18\xff\xa3\x1a\x39\x83\x57\xe5\x5a\x56\x3d\xee\x22\xf4\xde\x82\xed\xcd\x15\x1a\x0c\x31\xd8\xe5\x25\x09\xe3\xed\xd2\xb0\xc7\xa0\x1c\xac\x85\x05\x01\x6f\x5c\x8c\x64\x79\x2b\x3a\xa7\x1f\x08\x12\xcf\xec\x57\xdb\x09\xeb\x06\x1b\xf3\x00\x3b\xcc\x26\x93\xa7\xdc\x56\x8a\x96\xd9\xcc\x3a\xa3\x4a\x84\xd8\x69\x10\x60\x28\x6a\xa0\x57\xa0\x9c\x25\x5b\x38\x03\xa9\x88\xcb\x96\x5b\xe4\x76\x9b\x22\x9c\x34\x15\xb9\xfa\x41\x6d\x69\xea\x87\xb2\x39\xea\x18\xc4\x38\xf4\xf5\xbf\x83\x7e\x18\xa4\x94\x66\x58\xcc\xb3\xf9\x7c\x8e\xcd\xc7\xfc\xc4\x21\x10\x6d\x7c\x00\x24\x86\x20\x83\xc6\xf1\x61\x0d\x1f\xe9\x12\x6e\x06\x2f\xbd\x64\x25\xfa\x9f\x57\x26\xcc\xb0\x57\x04\x65\xbd\x94\x88\xad\x68\x4e\x64\x49\x68\xc9\x2b\x18\xd5\x56\xf2\x1d\x07\x03\xd3\x48\xae\x77\x7f\x07\x52\xe9\x56\x9e\xf3\x64\xb8\x0e\xbd\xf2\xde\x44\xdf\xe1\x17\x27\xf0\xcb\xb7\x34\x3f\x8d\x26\x2b\xe8\xbb\x95\xd1\xad\xf3\x7c\x15\xbe\x0a\xd0\x2d\xb7\x49\xcc\x2e\x7c\x42\xfe\xca\x5a\x32\x14\x68\x9a\x73\x00\x24\xc6\x99\x93\xd0\x08\x6f\x4d\x76\xa4\x68\x70\x22\x26\x8e\xa7\x30\x21\x85\x01\xa0\xc9\x87\xf8\x60\x4b\x86\x59\xd8\xd9\x7c\x18\xc9\x24\x1a\x9f\x58\xe3\x1d\x85\x7f\x10\x2a\x1a\x89\xc6\xa5\x09\x66\x93\xc9\xb7\x51\x71\xda\x5d\x05\x19\x02\xb0\xc0\x21\xa2\xb1\x92\x65\x6e\xf5\x94\x53\x43\x40\xc1\x82\x18\x34\x65\xb0\xbc\x3d\x73\xa6\xcc\xe0\x40\xe0\x62\x40\x9b\x1d\x73\x41\x5b\xc9\x76\x7f\x06\x17\xea\x8a\xc3\x93\x84\x4c\x1f\x45\x10\x61\xcb\xf6\xd6\xb7\x36\x20\xc2\x33\xa3\x63\x67\xbb\xcf\xbc\x7c\x92\x86\x9f\x81\x97\x2c\x5a\x8b\xf5\x70\x30\x4c\x18\xbe\x1c\xc2\xb3\xc4\xc5\xac\xee\x93\xc5\x0d\xb0\xce\xe0\x2f\x14\xca\x0f\x76\xca\x40\x51\x96\xd9\x37\xd6\xc9\xae\xf3\xfa\x4a\x76\x79\x9e\xfb\x51\x72\xdd\xca\x9c\xc7\xe0\x78\x19\x8f\x10\xc2\x66\xed\x18\x47\xa8\x38\x98\xf4\xca\x59\x58\x26\xd4\xe2\xd8\x61\x95\xc8\xc1\x9b\xa7\x2f\x7e\x7c\x7b\xff\x9e\xe8\x54\x7e\xb5\x78\x40\x4c\xc7\xba\xb3\x4b\x78\x62\x88\x33\x0e\xca\xbf\xad\xf6\x54\x1d\x7a\xf8\x14\xc8\x2d\x28\x68\x1a\x45\xad\x95\x16\x94\x83\x8d\xd8\xd1\xee\xb3\xa0\xf3\x79\x7f\xaf\x34\x7a\x26\xa8\x6a\x31\x92\x41\x9a\x0f\x35\x20\x86\x64\x07\xbe\xa2\x38\x4d\x95\x53\xa4\x86\xc1\xca\xd0\x76\x6f\x41\xad\x06\xbd\x1f\x92\x29\x5e\x53\x37\xba\xe1\x00\xe1\x2f\xc1\x5b\x4a\x36\xe8\x4b\x99\x06\x70\x46\x53\x5d\x68\x97\x93\x3d\x13\xa6\x42\xbe\xf2\xbb\xed\x0c\xfe\xc2\x5a\x90\x5c\xa5\x38\x73\x60\x23\x61\xf9\x55\x27\xab\x22\xea\x70\x2f\xcb\x4c\x1a\x56\xa2\xa9\x7f\x75\x70\x90\xa1\x83\x0f\x64\x87\x08\xfc\x5e\xc2\x03\x25\xc3\x33\x33\xf6\x23\xc0\x83\x3e\x7b\x33\x0c\xb3\x13\xb2\x19\x5e\xec\x86\x6d\x86\x37\x3b\x81\xd9\x97\x23\x33\x1d\x75\x73\x60\x76\xa4\x38\xe1\x70\x60\x13\x0b\x1b\x59\xd7\x69\xd0\x3c\xc4\xc0\xe3\xba\x30\x39\x55\x32\x15\x3c\xca\x68\x01\x49\x8f\x48\x2e\x54\x4b\x92\x55\x2a\xaf\x6f\x49\xce\x45\x76\xd0\x0f\x80\xfb\x28\x9d\x0f\xa2\x74\x46\xc5\xee\xa5\xad\xd6\xfa\x92\x22\xd5\x4e\x07\xa5\x94\x08\xf0\x6c\x32\x79\x11\x02\x8f\xc8\x27\xe6\x12\x9c\x11\xad\x55\xb2\x75\x3c\x79\xd8\x08\x09\x2b\x2f\x83\x1b\x5b\x64\x80\x7e\x9c\x36\x98\x6b\x6d\xa5\xdb\x68\x73\x19\xfa\x73\x84\x1c\x9d\xd6\x2a\x60\x86\xd5\xfc\xc8\x19\x0c\x8e\x20\x28\x52\xe3\x95\x6e\xd4\xdf\x64\x15\x5f\xaf\x45\xbd\x22\x04\x89\xba\x0e\x84\x59\xb2\xb3\x19\x35\x95\x47\x00\x27\x0e\x71\x70\x9f\x16\x25\xe7\x74\xd0\x5e\x29\xb2\x06\x53\x82\x37\x0d\xef\x5b\x27\xfa\x17\xb1\x19\x32\x15\x41\xbd\x04\x5c\xc6\xfc\x01\xab\xa0\x76\xeb\xcd\xa3\x97\x7e\x98\x94\xad\x83\x01\xe7\xa7\x28\x92\x38\x6f\x22\x1f\x7b\xbd\xf8\x73\x13\x23\x90\x0d\xbe\x28\xed\xdb\xfb\xf7\x2a\xb9\xec\x2f\xae\x84\xc1\xac\x82\x6f\xf5\xa1\x9d\xba\x8e\x9c\x81\x1b\xd7\xeb\xc1\x79\x53\x16\x95\x4d\x22\x69\x22\xee\x3c\xca\xd9\x51\x98\x86\x80\xc7\xb8\xb3\x4c\x5d\x20\xa4\x27\x66\xbd\x65\xa8\xbc\x60\x3e\xf8\x81\x86\x8f\x9c\x53\xa4\xdc\xb7\x25\x6c\x85\xec\x85\x0f\x54\x2d\xb7\x34\xcb\x6c\x32\x29\x8a\x62\x29\xec\x7a\xf2\xcf\x50\xf6\xa6\x86\xfc\x3f\xe1\xc5\xf3\x57\xaf\x21\xff\x23\x4c\x91\x6d\x9f\xfc\x3b\xa6\x09\x8f\x9c\x3e\x72\xd2\xba\x59\x69\xaf\xa6\x70\xb0\x7c\xc0\x27\x40\x26\x93\xdf\x27\x00\x53\xd6\x5c\xd3\x73\x98\xda\x9e\xea\x0f\xa6\x19\x36\x57\xc2\x89\xe9\x39\x60\x17\x80\xa9\xaa\xb0\xc3\x52\x9e\x3d\xfc\xe6\x51\xf9\x30\x2f\x4f\xce\x8e\xf3\x93\x52\x3e\xca\xc5\xf1\xe9\x37\x79\xb9\x3a\x59\x1d\x2f\x84\x78\xb4\x7c\x78\x32\x9d\x00\x7c\x98\x7c\x98\x50\x79\x83\x4f\xb8\xf0\x14\x05\xe4\x9c\x34\xdf\x4b\x4b\x0d\x79\x97\xcf\x4a\xb5\xc4\x44\xc9\x67\xe5\x46\xe8\xb3\x42\x71\x58\xf0\xb5\x27\x3e\xa8\x6a\xb4\x7b\x61\xa5\xc7\x46\xb4\x2e\x01\xf5\xfd\x8d\x04\x50\xd5\x93\xe3\xf2\xd1\x63\xf9\xe8\x9b\x79\xbe\x28\xe7\x55\x7e\xb2\x38\x91\xf9\xd9\x99\x38\xc9\x1f\x2e\xc5\xf1\xa3\xe5\xa3\x6f\xca\xf9\x6a\x7e\x1d\x45\x78\x9a\xcf\xa7\xc8\x27\xcd\x99\xf1\x17\xc3\xb0\x3e\xa5\x19\x5e\x88\x12\xb1\x8d\x6f\xde\x4c\x49\xd8\xa7\x19\x4c\xa3\xc0\x4e\xdf\xfa\x6e\xbc\x75\x47\x08\x00\xa6\x91\xd3\x09\x56\x9f\x67\xf1\xa3\x02\x4c\x7d\xc6\x04\x5f\x2e\xd6\xf3\x66\x6e\x87\x57\x21\x8d\x81\xef\xe6\xf3\xf9\x22\xa7\xff\xbf\x9e\xcf\xbd\x73\x38\xf4\xf4\x7e\xd6\xc7\x3b\x72\x16\x01\xfb\x21\xe7\x0f\x33\x29\x51\xcb\x12\xdb\x7f\x87\x69\xcc\x0b\x60\xb7\x7f\xc5\x65\xb2\xb4\x4f\xcf\x01\x7d\x65\xf8\x10\x3f\x8b\x71\x53\x5a\xda\xa5\xea\x86\x11\x93\xa0\xd5\xf4\x1c\xe6\xb1\x9d\x74\x27\xcf\xd3\x88\x77\x21\xda\x39\x3d\x87\x87\x19\x4c\xbd\x02\x26\x5c\xd8\x69\x32\x11\x59\xcf\xf8\xd5\x07\x6a\xf1\x2f\xa6\x8d\x74\x62\x44\x70\x60\x21\x47\x55\x80\x83\x44\x11\x1f\x10\xa5\xfe\x86\x6f\x16\xc7\xf3\xc7\x27\x43\xe3\x1a\x05\x14\x3f\x38\x5b\x3d\xfe\xa6\x9a\x3f\x5e\x3c\x7e\x7c\x52\x3e\xaa\xbe\x39\x3d\x13\xc7\x2b\x29\xc4\xbc\x3c\x3d\x15\xd5\x7c\x71\x2a\x1e\x2e\x57\x27\xab\xc5\xf2\x78\x39\x5f\x3e\x3e\x3e\x2e\xab\xc5\x69\xf5\x4d\xb9\x38\x5d\xce\x57\xf3\xb9\x98\x3f\x1e\x26\xc2\x7a\x10\xd9\xba\xd7\xdb\xce\x43\xf2\xce\x1d\x8d\x20\xf1\x36\x1c\x21\x59\xd4\xaa\x4c\x58\x82\xc3\x8e\x8c\x24\x0c\x14\x92\x56\xe1\x58\x61\x8a\x14\x2e\xbc\xa9\x98\xec\x69\xaa\xea\xf8\x30\x7f\xdc\xd8\x75\x84\xd7\xb0\x87\x1c\x60\x63\xa2\xf5\xe2\x78\x20\x28\x7b\x95\x23\x1a\x7b\xfb\x03\x3b\xc6\xb6\xc4\x0e\xd9\xe3\x07\x25\x99\x01\x42\x9b\xd3\x4e\xd4\xd3\x73\x38\x79\x3c\x1f\xb7\x3d\x7b\x27\x4a\xe7\xd9\x30\xbe\xf1\x11\xd0\xe9\x39\x1c\x9f\xc6\x46\x72\xb2\x5e\x4a\x81\x93\x3d\x9c\x1f\x2f\xc6\x2f\x5e\xfb\x09\xc6\x6c\xe0\xd6\x46\xf7\x17\x6b\x96\x8f\xe3\xd9\xf0\x8d\x24\x0e\x5b\x3c\x5a\xcc\x4e\x46\x68\x42\xd1\xf5\xf2\xf9\xfb\x57\x21\x4a\xec\x8a\xca\xe7\x0a\xe7\x39\x7d\x34\x1b\xf0\xc4\x36\x07\xa2\x73\x04\x16\x89\xde\xf4\x1c\xda\xbe\xae\x7d\x93\xd1\x9b\x67\xd8\x4a\xea\xcb\x7f\x1e\x40\x26\x39\xb2\x56\x90\x80\x61\x4f\x38\x7b\x94\x85\xad\x7b\x71\x7c\x0e\x4b\x61\x24\xfc\x3a\x05\xd5\x42\xab\xdb\x9c\x13\x5f\x39\x6d\xbc\x11\x42\x9e\x63\x7a\x8e\xdf\x0e\x4d\x1c\x1e\x40\x6c\x9e\x2c\x1e\x27\xed\x3c\x38\x11\x20\x69\x0d\xaa\xee\xec\x51\xf6\x93\x68\x71\xca\x9f\xbe\xff\x75\x0a\xdf\x6b\x99\xfd\x55\xb4\xf2\xdf\x7d\x5d\x1b\x56\x12\xa5\xf3\x96\xa4\x8c\x51\x44\x6e\x80\xd3\x77\x67\xf5\xf1\x36\xdd\x7d\x5f\x93\x3b\x84\x0c\x51\x80\xb2\xd1\x96\xf1\xc1\x07\x32\x80\x76\xa2\x14\xea\xdb\x8c\x9a\x6b\x61\x2e\x64\x78\x7b\xbf\x18\x38\x94\x06\xf2\x49\xa9\x07\xa0\x1c\xfe\x94\xd6\xa9\x46\xb8\x34\x60\x40\xac\x08\x46\x8a\x0a\xac\x86\x95\x30\x33\x28\x06\x1e\xa4\x41\x54\x1b\x8d\xf6\x4e\x1a\x5f\xca\x06\x7a\x35\xd4\xdb\xa0\x6f\xc7\x61\x23\x27\xc2\x27\xdc\x0d\x4d\xb5\xa7\x6c\xdc\x32\x08\x34\x93\x70\x3b\xde\x36\xbf\x2b\x75\x6b\x95\x45\x8d\x35\x1b\xdc\xcf\x21\xdf\xc6\x05\x2c\x36\x89\x50\xec\x7a\xa1\xd9\x90\xc5\xf5\xc5\x82\xbe\x68\xcc\xfa\xf8\x51\xf0\xb8\x6a\xe5\x7d\x45\xfe\x3c\x56\xbf\x70\x0c\x74\xfa\xaf\x53\x1f\x06\x9b\x9e\xcd\xed\xb4\x98\x41\x11\x94\x7c\xe1\xad\x9e\xa5\xb4\xc9\xf7\xa1\x4e\xf0\xbe\x36\xea\x42\xb5\xa2\x26\x3b\x30\x03\x54\xf4\xa0\x5a\x46\x72\x06\xaf\x7e\x78\x9a\x1f\x9f\x7e\xc3\xd5\x78\xb6\x6f\x68\x0e\xaf\xa3\xc1\x6d\x3b\x8c\x15\x6e\xd6\x7a\x18\x54\x79\x77\x88\x75\x71\x6a\xf6\x70\x7b\xd1\x09\x43\x09\x6d\x47\x86\x91\x03\x23\x73\xd3\xb7\x96\xc3\x70\x5d\x2d\xb6\x16\xd4\x0a\x6d\x78\xef\xa8\xfa\x30\x18\x22\xce\xeb\x88\x6c\x08\x86\xb6\x55\x2c\x4a\x43\x13\xd6\xdb\x1a\x05\x55\xa1\xf1\x6a\x7d\x13\x70\x1d\x97\xab\xb7\x68\x96\xeb\x0d\x65\xa0\x23\x68\xe7\x21\x10\x9e\x41\x11\x8b\x95\x86\x5a\xa5\xa4\x54\x69\xa8\x54\xc2\xf9\xbc\x7b\xae\x6c\xe2\x13\x8a\x91\xe7\x1e\x1d\xfb\x2e\xd2\x7b\xec\x3a\x7b\x3e\x8d\xe9\x51\xf2\x86\x94\xf3\x38\x38\x07\xe5\x2c\x14\x06\x63\xa0\xde\xbb\xbe\xdf\x6a\xef\x39\x84\x08\x07\x5b\x19\x0f\x32\xa2\xd9\x90\x11\xf1\x2e\x0c\x47\xd4\x0a\x56\x1d\x05\x8e\x7b\xd9\xea\x0d\xd7\x24\x1a\xb1\x81\xc2\x97\x4d\x17\x51\xd7\xa5\xe8\xf3\xae\xa3\x27\x23\x25\x8a\xb0\x34\xf4\x70\x56\xcc\x8b\xdc\x2c\x35\xcd\x8d\x2b\x20\x87\x57\xf8\x00\x02\x38\xee\xbd\x63\x96\xdf\xb6\x04\x6a\x28\x69\xba\x65\x19\x53\x62\xbc\x7f\x8a\xf5\x1e\x73\x12\xd7\xc0\x54\xa0\xeb\x5b\x1c\x4a\x65\xe0\x8b\x18\x73\xa5\x41\x9d\x4e\xa3\xfc\x31\xb3\x61\x31\x89\x38\x79\xde\xd6\xdb\x9d\x8c\x83\x2f\x9e\x65\xf7\xd7\xb7\x46\xff\xfb\x70\x16\x61\xdc\x79\x08\x84\xce\x3e\xe6\x7a\xc8\x6a\x29\x16\x8b\xc7\xcb\x7c\xfe\xb0\x5a\xe6\x27\xcb\xe5\x2a\x17\x67\x27\x65\xfe\x68\xbe\x5a\x9c\x9d\x1d\xaf\xd0\xb2\xbb\xc1\xf5\x30\xee\x73\x3c\x8f\x64\x4b\x1d\xaa\x9a\xc0\xc8\xdf\x7a\x69\x9d\xac\xf6\xdd\x0d\x2e\x67\x3c\xe4\x18\xb2\x24\x43\xce\x05\x95\x20\x46\xc5\x96\x77\xc6\x7e\x77\xc5\x7d\x9f\xcf\x7c\xb4\xbe\xbb\xe3\xbd\x71\xfa\xda\xb3\x5e\x84\xc3\xab\xd9\x51\x48\x86\xb7\xc0\x24\xfa\xe6\x79\x0a\x47\xc2\xfc\xfc\x90\x10\x6b\x49\x8f\x31\xd1\xf0\x9b\xc2\x77\x29\xbe\x2a\xf3\xd1\x8a\x6e\xc3\x7c\x34\xc0\x4d\xcc\xe7\x97\x71\x88\xfb\xfc\xf6\x01\x39\xbc\xa4\x27\x10\x69\x0d\xfb\xff\xc7\x41\x09\x0a\xd4\x11\xc4\xef\x27\x93\x97\x21\x24\x29\x06\x9a\xc5\xa4\x41\x29\x6b\xeb\xf3\x83\xbd\x95\x5f\x95\x94\x0c\xd1\x6d\x68\xc9\x23\x54\xd7\xc7\x2b\x0e\x86\x96\x70\xe3\xcf\xc1\x07\xd4\xd2\x54\x07\x85\x97\x0e\x12\xf4\xbf\xf5\x76\x26\x3b\xb8\xc3\xed\x2c\x86\x6b\x47\x3a\x85\xe5\xc1\x06\x96\x0a\xdb\x59\x9a\xaf\x95\xe8\x0e\xd0\x39\x00\x09\x8d\x8e\x95\xdd\x99\x3f\x57\xc0\xc6\x92\x72\xa3\x10\x32\x65\x73\xbf\xf2\x66\x26\xbb\xdb\xed\x65\xb2\xbb\x49\x9b\xdc\xc0\x89\x83\xdd\x09\xf9\x10\xee\x1e\xf6\x34\xd6\x75\xd5\x11\xef\x86\x77\xcc\x91\x77\xc9\x94\x5f\xc4\x97\xc3\x82\xef\x8c\x35\xe3\x90\x87\x76\x3c\x2f\xe3\x3b\x09\x7c\xf6\x12\x9d\x34\xa6\xef\xe8\xbb\xb8\xbd\x0d\x66\xbe\xdd\xdd\xe8\xc2\x3c\x77\xb8\xd9\x61\x67\x5c\xd7\x93\x63\x7b\x1d\xa7\xc6\xd5\xdd\x86\x5d\x23\xe8\xba\xbd\x89\x6b\x07\x4c\x1e\x64\x5c\x34\x54\x8f\x7e\x57\xd5\x87\x23\x3e\x7b\x51\x40\x0e\x3f\xf0\xe1\x8b\x34\x46\xff\x33\xb9\x1c\x5c\x07\xe9\xcf\x98\xe8\xd5\xc1\xd4\xef\xe0\x3e\x71\xae\x19\xfd\x17\xa8\xa4\x51\x57\x21\x48\xa0\xd0\x19\xe7\xd0\x8f\x77\xdb\x82\x9e\xe1\x9a\x87\xc4\xd1\xdf\xa1\xc7\x35\xd8\xa4\x35\x7c\x0a\x71\xfc\x1a\x3f\x07\xeb\xfc\x45\x1a\x5f\x82\x29\xae\x02\x3f\xa3\x50\xb9\xc6\xa7\xc4\xf8\xa7\x46\xd5\xc8\xeb\xa3\x5f\x14\xf7\xf2\x71\xf3\xad\x75\xb2\xc1\x26\x2e\x89\xc0\x36\xef\x46\x27\x21\xd1\x64\xca\xdd\x89\x74\x62\x63\x7f\xce\xc4\x8b\xf9\x0c\xff\xf7\xe8\xf0\x2c\xc9\x80\x7a\x94\x33\xb8\xe5\xd2\x6c\xad\xdd\x70\xac\xf4\xf0\xdc\xe9\x5c\x3a\x35\xe2\xae\x9f\x7c\x71\xfe\x70\x67\x72\x1f\x79\x4e\xe7\x6e\x04\x2a\x87\x16\xcd\xa2\xc3\x13\xa7\xf3\xc4\x89\x6f\x44\xeb\xe2\xfc\xe1\xe2\xe3\x8b\xee\xd8\xbd\xe9\xba\x5a\x11\x55\x39\x56\xf7\x8f\x8b\xb5\x9e\x2d\xf6\x23\xad\xc7\x27\x3e\xd6\x7a\xb3\x8a\x08\x47\x3f\x72\xf8\xde\x17\x03\x70\x18\x8d\x9a\x27\x93\x57\xce\x48\xd1\x8c\x6b\x8f\x92\x33\x81\xa3\xe3\x3a\xe9\xb9\xb0\xbd\x0a\xab\x51\x0c\x8c\x93\xe0\xa5\xc6\x20\xa9\x0b\x93\x81\xb2\x03\x23\x71\xf2\x39\xaa\x20\x8a\x52\xfa\xb0\x53\x91\x94\x59\x85\x88\x63\xdc\x30\x38\x3c\xe9\x13\xe1\xec\x2d\x05\x55\x74\xe0\x78\x1e\x14\xff\x99\x3f\xa7\xc9\xf3\x17\xc2\x38\x25\xea\x62\xc8\x17\xfb\x7a\xcb\x19\x3c\xa7\xc2\x24\x56\x2d\x3e\x3b\x2c\x5a\xbb\xa1\x9a\x2a\xae\x08\x38\x99\x9f\xe1\xe9\xc7\x55\xad\x4a\x77\x68\xcf\x79\x0e\xf9\x4f\xb7\xd7\x74\x9e\x26\xd7\x10\x32\xad\x2c\xd9\x21\xe6\xf0\x6a\x4c\xd0\x9d\x22\x91\x61\x73\x48\x8f\x64\xa6\xe3\x76\xba\x56\xe5\x36\x63\xe2\x30\x76\x43\xa4\x4d\x1b\x6f\x15\xce\xe0\x59\x7a\x14\x63\xed\x8f\x59\x8c\x22\x6a\xb1\xfe\xfb\xaf\x92\x0a\x16\x83\xad\x49\x1d\x7d\x1c\x2f\xd2\x78\x88\x98\xdd\xfd\xe6\x91\x62\xc6\xe8\x4d\x46\x73\x67\x38\xdb\xe4\xec\x51\x76\x6d\x8a\x61\x7a\x4d\x8a\x21\x26\x04\xa6\xd3\x9f\xbe\x9f\x5e\x93\x10\xb8\x8e\x80\x1c\x8b\x65\xf7\x15\x9f\x0e\x05\xfa\x26\x93\xef\x48\x93\xd8\x40\xa8\x24\x14\x80\x47\x87\x77\xc8\x1a\xbf\x63\xd2\xee\xd1\xca\x47\x89\xc9\x1a\xf3\x34\xb5\x83\x3c\xc3\x85\x76\xb0\x52\xef\x64\xc5\xf2\xda\xca\x0d\x4f\x1a\x68\x6a\xa9\xdc\x8e\x03\xee\xa3\x12\x42\xa2\x63\x3c\xda\x96\xa5\x55\x9b\x9e\xaa\x69\x91\x9a\x6e\xa9\x64\x31\x03\xc1\xb6\x5c\xac\x5f\x8c\x95\x8b\xa2\x95\x1b\x5f\x25\x62\x7d\xc5\xdf\x10\x86\x0d\xdc\x14\xc3\xea\x7c\x1a\x7f\x95\x94\x72\xce\x76\x4f\x82\x7e\xa9\x79\xbe\x73\x96\xf3\x4b\x2d\xf3\xbd\x73\x90\xe9\x31\x89\xf4\xf4\x23\x55\x99\xb7\xd6\x49\x51\xed\x2d\x53\xb7\xf2\x86\x22\x86\x5b\x8b\x06\xb3\xe3\xe7\x97\x30\x3c\x5c\x2d\xc4\xa3\xf2\x58\xe6\x67\x62\xbe\xcc\x4f\xca\x45\x95\x3f\x96\xc7\xab\xfc\x74\xf9\x8d\x78\x54\x3e\xae\xce\xe4\x7c\x15\x4c\x5a\xcf\x9d\x94\x5e\xbb\x79\x9b\x32\xd2\xf4\x2d\xcb\x46\x4e\x27\xc9\xbd\x11\x7b\xbd\x38\xec\x6d\x3a\x2c\x03\xe9\x36\x92\xa4\x0d\xb2\x24\xd1\x50\xf1\x51\xa8\xa4\xf0\x90\xc6\x26\x6f\x37\x8b\xb5\xc6\x3c\xb6\x2f\xfe\xa4\x77\xb7\x93\x12\x36\x9b\x43\x00\x99\xb9\x3d\x5e\x11\x00\x21\xb5\x94\x56\xed\x46\x61\xe2\xcc\x0f\x17\x32\xc5\x6c\x0f\xbb\x52\x23\x8e\x19\xce\x59\xc7\x63\xde\x3f\xbd\x7a\xfe\x27\x58\xea\x6a\x0b\x4e\x5c\x4a\x3b\x64\xd4\x3c\xc0\xa0\xaf\xa4\x31\xaa\xda\x1b\xcb\x9f\x26\xe4\xa9\x0b\x4e\x24\x95\xbe\x57\x93\xc5\x63\x0e\xa2\xad\x32\x4e\xa4\x61\x0a\xcb\xe8\x3a\xa4\x87\xb2\x83\x27\xcc\x67\xf0\x23\xc5\x30\x3a\xbe\x07\x83\x36\xd7\xe3\xf9\x02\x98\xcc\xd5\xb0\x2b\x8c\xdd\x94\x80\xf3\x99\x17\x23\x56\x1f\x1e\xf4\x4a\xb6\x08\x2d\x9a\x2b\xc9\x81\xd9\xdf\x93\xfa\x97\xa1\x68\x63\x1a\xaa\xe1\x91\x25\xe7\xf3\xf9\x3c\x83\x29\x57\xc4\xa3\x45\x85\x0d\x1f\x3e\x7c\x28\xc0\x69\xd6\xb6\x9e\xdd\x10\x06\xeb\x8b\xbe\x3f\x16\x13\xf9\x01\xa6\xdf\x71\x32\x2f\xc7\x8a\x8b\x73\xb6\x22\x4b\xf2\xf9\x8e\xf0\x70\xec\x14\xf2\x0a\xfe\x30\x02\xef\x70\x5d\xce\x87\x0f\x7f\x80\x5f\x27\x00\x70\x17\xb2\x6e\xfa\xf6\x3a\xd1\xeb\x8c\xbc\x52\x72\xc3\x51\x39\x7a\x4c\x6d\x2f\x0c\x2e\x89\x6a\xff\x28\xa5\x1d\x71\xea\x60\x59\xf0\x51\xb8\xed\x5e\xc5\x74\x93\x0d\xc7\x1d\x28\xd7\xeb\x4b\xda\xc7\x8a\x1c\x4d\x4c\xe1\xd0\x67\x4d\xbc\xfe\xb8\x81\x45\xeb\xe5\x86\x23\x25\x4e\x73\x66\x95\x00\xf1\xc5\x44\x21\x8a\x1e\x01\x50\x2e\x54\xe3\xf3\x7a\x87\xf4\x72\x3c\x06\x1a\x32\xa6\xd4\xe8\x97\x5c\xa4\xe5\xb9\x23\x83\x07\xd9\x76\x28\x70\x57\xe1\x44\x11\x1f\x18\x4f\xad\x1e\xae\x1a\xd4\x7d\x5d\x8d\x4e\x6d\xfa\x44\xe6\x66\x38\x6f\x6a\xf8\x04\x44\x81\xb1\xba\x22\xe2\xdc\x67\x20\x93\xc3\x00\xf8\x53\xb5\x2b\x69\x8c\xac\x28\x7d\x8c\xb4\xf0\x4e\x3f\x59\x35\xe7\x50\xa0\x17\x75\x21\x49\x09\x32\xbc\xf8\xb4\xd4\xba\x96\xa2\x2d\x32\xd6\x84\xd6\x89\xa6\x2b\x48\xb0\x0d\x85\xa4\x51\x45\xd2\x6d\x26\xc5\x28\xc1\x8a\x64\x19\xac\xbc\x65\x2d\xda\x4b\xae\xaa\xb7\x3b\x9b\xf0\x57\xba\x85\x61\xb4\x43\x7f\xa5\x4b\x16\xc2\xc1\x4f\x4f\xf4\xf4\xec\x6b\x72\x5e\xcd\x48\x51\xd1\x91\xd2\xf4\xc8\x16\x97\x66\xe0\x39\xd3\x79\xf1\x09\x2b\x29\x2a\xb3\x7d\x89\x1b\x1f\x1d\xff\xb6\x1a\x70\xef\x1b\x39\x5d\x21\xa9\x1e\x2f\x48\x08\xfb\x13\x7a\x69\x51\x6b\x93\xe7\x70\xe8\xf8\xb1\x0f\xbf\x55\x86\x2f\x73\xc2\xaa\x57\xbb\x7b\xf0\x8e\x2e\x7e\x21\x33\x04\x3d\xed\xfd\x5b\x1a\x46\x15\x07\xbe\x96\xb8\x37\xbe\x06\x83\xe5\x25\xb2\x22\x43\x02\xda\xf8\xdc\x7d\x64\xc6\x90\xe0\x1a\x8c\x9d\x64\x01\xcd\x0c\x92\xda\x6c\x5c\x21\x68\x76\xca\x54\x25\x41\xae\x56\xb2\x74\x9c\x9b\x65\xd9\xe6\xd2\xdc\x1f\xed\xf7\x1e\x7b\xba\x1d\x2e\x09\x72\xde\x29\xc5\x8c\x3a\x8f\xbd\xa7\xac\xa7\xb7\x56\xa8\x5e\x71\xfc\x9b\xc7\xe2\x93\xc5\xbf\x30\x21\x9f\xa0\x4b\x39\xfd\x1c\xa3\x2a\x16\x34\xbe\x41\xfb\x2a\x83\x29\x55\x0c\xbe\xdd\x33\xa0\xde\xfc\x1e\xea\x9a\x16\x19\x4c\x59\xbb\xd0\x57\x0b\xfc\xe8\xdd\xf4\x2d\x7c\x08\x1f\xa1\xca\x18\x95\xa6\xf9\x2a\xa7\x9d\x90\x58\x28\x4d\xe4\x69\x9d\x2f\x0f\xf4\xba\x02\x9b\x48\xfa\xb1\xa6\x6b\x14\x7b\x09\x9f\xd1\xdf\xc9\x87\xac\x37\x76\xbe\x1b\x05\x4e\x18\x45\x69\xd8\xe4\xc6\xc5\xd3\xf2\x6d\x5f\xbb\xfd\xe5\xc7\xda\xd1\xd1\xf2\xaf\x0d\x87\x50\x3d\xf9\x11\x16\x94\x17\x90\xc3\x2f\x5c\x51\x3e\x99\xbc\xc2\x13\x7c\xd1\xfc\xc2\xb6\x9d\xb3\x7d\xc2\x92\xfd\x94\x1e\x33\x43\x19\xa9\x84\xa9\xa0\x90\xef\xba\x2b\x61\x0a\xe8\x44\x79\x29\x2e\xe4\x79\x52\xd9\xee\xab\x57\xda\x44\x6b\xd0\x1b\x7c\x1c\x95\xb0\x83\x55\xf1\xa4\x04\x4f\x19\xcb\x15\x76\x6a\x5a\x8a\x46\x36\xc8\x4f\xd6\xdb\x53\x65\xe3\xad\xca\xb1\xb4\x7e\xaa\x0b\x3d\x60\xc4\xf3\xaa\x1f\x8f\x50\x3a\x8b\x37\x5f\x31\x29\xa6\x61\x6e\x24\x1d\xcc\x66\x33\xe6\x87\xa4\x60\xf2\xe4\x78\x84\xf1\x1f\xdb\x2b\x51\xab\x2a\x98\x82\x93\xc9\x53\x7e\x18\xe2\x47\xb8\xf9\x85\xaa\x21\xbf\xa7\xf9\x72\xa2\xbd\x62\x73\x6f\x5e\xfa\x50\x1f\x88\xe1\xce\x2c\x7e\xfb\xb1\x70\xcd\x2e\x26\xc7\xf3\xfc\x03\xb2\xe5\x5c\x14\x79\x73\xb6\x40\x79\x0c\xf2\x09\x15\x44\xde\x39\xaa\xbf\x56\xfb\xa2\x02\x8f\x9b\x90\xb6\xb3\x11\x29\xfb\xc9\x84\xf8\x66\x47\x30\xe8\x5a\xaa\xab\xc5\x50\x90\xe0\x23\x5f\xb8\x0b\x11\x33\x56\x20\x2c\x18\xc9\xd7\x14\x5a\xe8\xdb\x4a\x1a\xba\xd1\x4d\x1d\x5d\x2d\xd0\xe9\x17\x97\x44\x90\xb8\x21\xe0\x2f\x94\x17\x3c\x25\x42\xb9\x8d\x91\x2a\x6f\xa4\x5b\x6b\xda\x7c\x44\x3b\x1c\x23\x0b\x97\x9b\x1d\x22\xe2\x29\x8a\xea\x5a\x57\xf0\x27\xed\xf8\x72\x94\x18\x1c\xec\x5b\x2e\xb9\x4a\xce\xf8\x16\x27\xf3\x13\xea\xf9\x47\xdd\xb7\x55\x41\xd6\x88\xf4\x57\x6d\xdd\xb8\x0d\x7f\xd5\xdb\xa2\x0e\x5f\x0e\xf5\x77\xbe\x2d\x8a\x4d\x0d\xe2\x6a\x4f\x3e\xde\xeb\xf6\xaf\xe9\xf1\x8e\x58\x14\xbd\x8f\xb9\x6a\x83\x91\xfe\x89\xb8\x28\xfe\xe3\xd9\xc7\x80\x80\xf7\x80\xa9\xab\x41\x4b\x66\xa0\xeb\x4a\x5a\xe7\xfd\x8f\x3b\xa0\xc8\x3e\x14\xe4\x0a\xed\x9c\xda\x7f\xb5\xa7\x89\x84\xf5\xac\xce\xa7\x7e\xdf\xc4\xa3\x32\x7c\x20\xdd\xf5\x36\xcf\xf9\xd6\xc6\xdc\xff\x4c\x2e\x43\xdb\x87\xe2\xc5\xd3\xd7\xdf\xfd\x70\x33\x1c\xef\xe1\x59\xa5\x5c\x5a\xff\x49\x27\x83\xd3\xe8\x5a\x00\xef\x4b\x71\xf1\xfd\xb3\x9f\x9f\xbd\x7e\x76\x23\x18\xef\xe1\x7b\x49\x91\xfc\x21\xc6\xb8\x9b\x39\x0c\xa7\xd0\xed\x17\x42\xb1\xcf\x9d\x04\xc3\x79\x72\x9d\xc2\xfb\x58\xf4\x38\xd2\x7d\xb8\x97\xf8\x6d\xf3\xd6\x7c\x71\x1d\x14\xbe\xf0\x8d\xfa\x1d\x2c\x7e\xbb\x4b\x7d\x71\x2d\x14\xa1\x00\x0a\xfb\x1d\xac\x81\xfa\xbb\x40\xc1\x25\x3c\x01\x17\x1f\xaf\xe3\xf9\x3a\x50\x24\xe5\x1b\xef\xe1\xf5\x27\x95\x6f\xdc\xb9\xbe\x38\xfa\x3d\x6c\x8f\x1f\x0a\x5f\x77\x51\xf8\x94\x7c\x16\x2f\x5d\xcb\xc6\x29\x16\x4e\x9e\x87\x90\xcb\x58\xb7\xf8\x6d\x37\xec\xb4\xa9\x24\xde\x84\x8b\x98\x59\xf0\x7c\x81\x3f\x6e\x4e\x14\x7c\x29\x36\x3e\x02\x05\x85\x70\x3d\x14\x7d\xbb\xa7\x2c\x38\xbe\x6a\xa5\x84\x37\x5c\xa2\x3e\x9c\x5a\xa6\x6f\xf3\x3c\xe7\xf6\xc3\x2a\xf3\x63\x50\x9c\xef\xde\xbc\x12\xf4\x85\x45\x0f\xd4\x87\x47\xad\x07\x60\xd9\xd7\x97\xc1\x3e\x7d\x7b\xff\x1e\xfe\xcc\xfd\xcf\x07\xb7\xc2\x45\xaa\x2a\x46\xfa\x62\x04\x05\x7c\x65\x49\x4d\x55\x05\x8c\xf4\xc5\x57\x01\xe3\x7a\x8a\x44\x55\x11\x29\x22\xbb\xaf\x84\x8a\x6b\xa1\x48\x54\x05\xf7\x4b\xf4\xc5\xdd\x83\x12\x2f\xbe\xa1\x23\xd2\x6c\x2c\x0d\xde\x07\xde\xc5\x49\x71\x7e\xd5\x52\x2c\xde\xc9\x16\x55\x44\x88\xc6\xab\x76\x88\xd0\xa7\xc6\x86\xf7\x91\x92\xa8\x38\xf3\xb2\x6a\xcb\xba\xa7\xe0\xfd\x4e\x66\x61\xb8\xe7\xd5\x47\xf0\x67\xf0\x67\x6f\x33\xeb\x24\x84\x1e\x32\xb4\xe1\xf6\x03\x8e\x8f\x4f\xcf\x21\x44\x53\x87\x53\x36\x2a\xb9\xbd\xfb\xc0\x55\x3d\x33\xf8\x6e\x9c\x00\xa0\x84\xc3\xde\x7d\xb3\x59\x4c\x05\xb0\x26\xdc\x50\x99\x19\x87\x33\x66\xf0\xb3\x37\x71\x8c\x04\x59\xa9\x88\x38\x11\x57\x01\x7a\x89\x00\xa3\x10\xbb\x18\xfd\xe2\x77\xa1\x10\x81\xd3\x87\x1c\x92\xe4\x23\x30\x8d\xbe\xf2\x5d\x1b\x50\x2b\x28\xf0\x34\x5a\x91\xed\x19\x52\x61\xaa\xa4\x8d\xcf\x6d\xec\x25\x3f\x42\x86\x21\xe9\x49\x21\x8a\x10\xa1\x98\xcf\x3f\x64\xf0\x3b\x07\x69\x38\x34\x42\xce\x58\x06\x53\x02\x6b\x7a\x1e\xcf\x2a\x7e\x78\xfb\xa1\x98\xa5\x0c\xe3\xfd\xa1\xe5\x16\x3a\xe1\x6f\x69\xf1\x77\xe6\x41\x41\x67\x61\xc3\x41\x85\xfb\xa7\xf3\xe4\xf6\x8a\xcc\x07\x1e\x4f\xe7\xf3\x07\x78\x29\x09\x27\x50\x7d\x0c\x19\xe3\x41\x99\xaf\x2a\xbe\x90\xfe\x26\x94\x02\x8b\x37\xbe\xeb\x0d\xa5\x27\xa9\xf4\xdd\x5a\x10\x16\x8a\x72\x68\xa3\xaa\xbe\xe4\x0e\x1b\x0e\x9a\x13\x4a\xfc\xd1\x87\x95\xaa\x9d\xcf\xe0\x56\x60\x35\x32\x44\x12\x2c\xf9\xad\xc7\xc8\x5f\x27\x8c\x68\xa4\x93\xc6\xc2\x52\xd6\x7a\x13\xf0\x37\x12\xd2\x7f\x63\x1c\x3d\x89\x19\xbb\x7f\x09\x25\x35\x2b\x27\xcd\x93\xf4\x42\xaa\x79\xbc\x90\x2a\x24\xd9\x10\x99\xbd\x91\x21\x9c\x42\x67\x6a\x5a\xbc\xfe\x80\x9c\xc0\x38\xff\xdf\xc5\xe1\x1b\x69\xa3\x7f\xcc\x9d\xc0\xf1\x06\x30\xf4\x4c\x22\x38\x40\x67\x5e\xe2\x3f\x1a\xa1\xdb\x18\xe9\xdd\xbd\x3e\x90\xbf\x1c\x2e\x3b\xf5\xc7\x18\xc2\xed\xdb\x1f\xd7\xc1\x29\xe9\x8a\x9d\xa9\xfd\x3b\x10\x0e\xb4\xf1\xc5\xc7\x6e\xad\xac\xbf\xe4\x66\xff\xfe\xb1\xf9\xa7\xde\x3f\x96\x4e\xfd\x2d\x25\x7c\x8a\xc3\x53\xc7\xab\xa6\xfc\xac\x77\xb4\xf3\x84\xe3\xde\xc5\x01\x84\xf3\x95\x46\xa3\x0a\x06\xec\xca\xb7\x24\xc9\x50\xf2\xef\x9c\x34\x6d\xc0\x81\x15\xb5\xb4\xf9\xff\x98\xd1\x1d\xbb\x1f\x99\x3a\xe4\x84\x0f\xd1\x9a\x4d\x2a\x72\x54\xe2\x81\x40\x16\x50\x65\x59\xb7\xdf\x6a\xd5\xa4\x7c\x0b\x38\x38\x75\xbc\x03\x8a\xee\xf7\xa0\x9e\x60\x65\x4d\xff\x88\xc0\xae\xd1\x75\xdd\x75\x94\xff\x74\xf0\x3e\x4a\xe6\x70\xbd\x63\xee\x05\xea\x8f\x2f\x3f\x1d\xe8\xa2\x4d\x14\x8b\x0c\x3a\x23\xa9\xf6\xc5\x87\x36\x72\x56\x27\xa8\x1a\x64\x5b\x31\xb6\x2a\x69\x02\x58\x79\x1c\xfa\xfd\xad\x72\xbf\x9c\xd0\xe5\x0c\xf0\xe1\xfb\x01\xe2\x81\x7d\x6c\x5e\x64\xe2\xd7\xf6\x38\x5b\xfe\xda\xfa\x17\x37\xa7\x8c\xb3\x43\x17\x21\x7c\x2c\x8f\x9c\xaa\xe1\xc9\x97\xe7\xb3\x0f\x97\x6d\x66\x5c\x58\x8d\xcd\xa7\x76\xfa\x19\x90\x7c\x52\x1e\xe6\xfc\xef\x73\x1e\xe9\x50\x1d\xf6\xb7\x89\x03\x11\x36\xf0\xf1\x79\xc0\x2c\x9e\xff\x8a\x87\x36\xfc\x75\x60\xa0\x4d\x7a\xc1\x8e\xd3\x17\x94\xf6\x4d\x2f\xf0\xc3\x4d\xd6\x4b\x8e\x32\x50\x28\x9f\x4a\xf6\x36\x0a\x8b\x52\x11\x64\xa9\x38\xbf\xf1\x0a\x58\x32\xb4\x2e\xe5\xf6\x9f\x42\x03\x2e\x57\x19\xba\xe8\x26\xc6\xe4\xeb\x9a\x2e\x54\x06\xba\x3b\x09\x1a\xe9\x32\x10\xd7\x7e\xe5\x8b\x4f\x1a\xe9\x86\xcb\xef\x86\x0b\x55\x85\x63\x00\x67\x30\x0e\x2d\xfb\x0a\x91\x5a\xd2\x79\xe7\x78\x7c\x38\x4b\x93\x87\xa5\x6e\x64\x72\x7f\x6f\x28\x0e\xf5\x88\x29\x7c\x49\x6e\x91\x25\xb7\x71\x6d\x76\x0e\xc3\x55\x5a\xda\x98\x5f\x48\x4e\x2c\x67\x6c\xb7\xae\x28\x3c\x4c\x73\x84\x28\xf2\x8f\xdf\x5b\xbe\x05\x94\x8c\xb2\x62\x06\x3f\x2b\x34\x5d\x69\xca\xbd\xb2\x16\xca\xe0\x7f\xbe\x6d\x7b\x07\x15\x23\x81\xdc\xac\x2f\x0e\xa9\xc9\xeb\xcb\xa7\x3f\x43\xf2\x3e\x5f\xa8\x92\x34\xdd\x90\x27\xe4\x6a\xb1\x4f\x4a\x9f\x64\x61\x08\xfc\x22\x54\x5d\x67\x87\x4e\x05\x26\x99\x48\xff\xf7\x97\x5c\xad\x93\x00\x3d\x3d\x1f\x2e\x09\x49\x5e\x1e\x48\x9f\x64\x07\x2f\xa9\xf8\xf2\x44\xcd\xf8\x1e\x88\xa0\x77\xfa\xa6\x11\xe1\x56\x9a\x80\x09\xce\x79\x26\x57\x99\x8c\x92\x9c\xff\x6f\x00\xf7\xb1\x44\x4d\x3d\x6c\x00\x00")
 
 func readmeMdBytes() ([]byte, error) {
 	return bindataRead(
@@ -94,7 +94,7 @@ func readmeMd() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "README.md", size: 2826, mode: os.FileMode(420), modTime: time.Unix(1598118098, 0)}
+	info := bindataFileInfo{name: "README.md", size: 27709, mode: os.FileMode(420), modTime: time.Unix(1792319637, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -5,4 +5,5 @@ go 1.15
 require (
 	github.com/google/uuid v1.1.1
 	github.com/yuin/goldmark v1.2.1
+	go.etcd.io/bbolt v1.3.5
 )
@@ -1,13 +1,16 @@
 package main
 
 import (
+	"flag"
 	"log"
 	"math/rand"
 	"net/http"
 	"os"
+	"path"
 	"time"
 
 	"github.com/prmsrswt/pipeline/pkg/api"
+	"github.com/prmsrswt/pipeline/pkg/store"
 )
 
 const (
@@ -15,6 +18,12 @@ const (
 )
 
 func main() {
+	checkpointInterval := flag.Duration("checkpoint-interval", 10*time.Second, "How often running tasks persist their progress.")
+	concurrency := flag.Int("concurrency", 10, "Maximum number of tasks running at once, 0 means no limit.")
+	releasePaused := flag.Bool("release-paused", false, "Let paused tasks free their slot for queued ones.")
+	dbPath := flag.String("db", path.Join(uploadDir, "pipeline.db"), "Database keeping the tasks across restarts, empty to keep them in memory only.")
+	flag.Parse()
+
 	// Set up directory for uploads
 	err := os.MkdirAll(uploadDir, 0755)
 	if err != nil {
@@ -33,7 +42,25 @@ func main() {
 		w.Write(index)
 	})
 
-	pipelineAPI := api.NewAPI(uploadDir)
+	opts := api.Options{
+		UploadDir:          uploadDir,
+		CheckpointInterval: *checkpointInterval,
+		Concurrency:        *concurrency,
+		ReleasePaused:      *releasePaused,
+	}
+	if *dbPath != "" {
+		db, err := store.OpenBoltStore(*dbPath)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		defer db.Close()
+		opts.Store = db
+	}
+
+	pipelineAPI := api.NewAPI(opts)
+	if err := pipelineAPI.Restore(); err != nil {
+		log.Fatalln(err)
+	}
 	pipelineAPI.Register(mux)
 
 	log.Println("Web server started")
@@ -0,0 +1,189 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prmsrswt/pipeline/pkg/task"
+)
+
+// bulkConcurrency is the number of tasks a bulk request controls at once.
+const bulkConcurrency = 16
+
+// Outcomes of a bulk control action on a task.
+const (
+	bulkApplied  = "applied"
+	bulkSkipped  = "skipped"
+	bulkNotFound = "not-found"
+	bulkFailed   = "failed"
+)
+
+// bulkRequest is the body of a request controlling several tasks, selected
+// either by ID or by their labels.
+type bulkRequest struct {
+	IDs []string `json:"ids"`
+	// Selector selects the tasks by label, e.g. team=billing,env!=prod.
+	Selector string `json:"selector"`
+	controlRequest
+}
+
+// bulkResult is the outcome of a bulk control action on one task.
+type bulkResult struct {
+	ID     string      `json:"id"`
+	Result string      `json:"result"`
+	Status task.Status `json:"status,omitempty"`
+	// Message tells why the action was skipped or failed.
+	Message string `json:"message,omitempty"`
+}
+
+// handleBulk applies a control action to several tasks at once, requested
+// at /api/v1/tasks:{action}, and responds with the outcome for every task.
+func (a *API) handleBulk(w http.ResponseWriter, r *http.Request) {
+	action := strings.TrimPrefix(r.URL.Path, v1Prefix+"/tasks:")
+	c, ok := a.controls[action]
+	if !ok {
+		respondError(w, "unknown action", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, http.MethodPost)
+		return
+	}
+
+	var req bulkRequest
+	if err := decodeBody(w, r, &req); err != nil {
+		respondBodyError(w, err)
+		return
+	}
+	req.Selector = strings.TrimSpace(req.Selector)
+	if (len(req.IDs) == 0) == (req.Selector == "") {
+		respondError(w, "either ids or selector is required", http.StatusBadRequest)
+		return
+	}
+
+	wait, err := parseWait(req.Wait)
+	if err != nil {
+		respondError(w, "invalid wait duration", http.StatusBadRequest)
+		return
+	}
+
+	ids, tasks, err := a.bulkTargets(req)
+	if err != nil {
+		if errors.Is(err, task.ErrInvalidSelector) {
+			respondError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		respondError(w, "error listing tasks", http.StatusInternalServerError)
+		log.Println("[error] listing tasks: ", err)
+		return
+	}
+
+	cause := clientCause(r, req.Actor, req.Reason)
+	results := make([]bulkResult, len(ids))
+	sem := make(chan struct{}, bulkConcurrency)
+	var wg sync.WaitGroup
+
+	for i, t := range tasks {
+		if t == nil {
+			results[i] = bulkResult{ID: ids[i], Result: bulkNotFound, Message: "task not found"}
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, t *task.Task) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			results[i] = bulkControl(r.Context(), t, c.op, cause, wait)
+		}(i, t)
+	}
+	wg.Wait()
+
+	summary := make(map[string]int)
+	for _, res := range results {
+		summary[res.Result]++
+	}
+	respondSuccess(w, map[string]interface{}{
+		"results": results,
+		"summary": summary,
+	})
+
+	log.Printf("[success] bulk %s of %d tasks: %v\n", action, len(results), summary)
+}
+
+// bulkTargets returns the IDs of the tasks requested by a bulk request along
+// with the tasks, which are nil for unknown IDs.
+func (a *API) bulkTargets(req bulkRequest) ([]string, []*task.Task, error) {
+	if len(req.IDs) > 0 {
+		var ids []string
+		var tasks []*task.Task
+		seen := make(map[string]bool)
+
+		for _, id := range req.IDs {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			t, _ := a.store.Get(id)
+			ids = append(ids, id)
+			tasks = append(tasks, t)
+		}
+		return ids, tasks, nil
+	}
+
+	sel, err := task.ParseSelector(req.Selector)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The empty selector matches every task, which is never what is meant here.
+	if len(sel) == 0 {
+		return nil, nil, fmt.Errorf("%w: no requirements", task.ErrInvalidSelector)
+	}
+
+	all, err := a.store.List()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var ids []string
+	var tasks []*task.Task
+	for _, t := range all {
+		if sel.Matches(t.Metadata().Labels) {
+			ids = append(ids, t.ID)
+			tasks = append(tasks, t)
+		}
+	}
+	return ids, tasks, nil
+}
+
+// bulkControl applies the control operation to one of the tasks of a bulk
+// request. Tasks whose status doesn't allow it are skipped.
+func bulkControl(ctx context.Context, t *task.Task, op func(*task.Task, task.Cause) error, cause task.Cause, wait time.Duration) bulkResult {
+	res := bulkResult{ID: t.ID}
+
+	if err := op(t, cause); err != nil {
+		var terr *task.TransitionError
+		if errors.As(err, &terr) {
+			res.Result, res.Status, res.Message = bulkSkipped, terr.Status, terr.Error()
+			return res
+		}
+
+		log.Printf("[error] controlling task %s: %v\n", t.ID, err)
+		res.Result, res.Status, res.Message = bulkFailed, t.Status(), "error controlling task"
+		return res
+	}
+
+	waitSettled(ctx, t, wait)
+	res.Result, res.Status = bulkApplied, t.Status()
+	return res
+}
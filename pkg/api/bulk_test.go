@@ -0,0 +1,111 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/prmsrswt/pipeline/pkg/task"
+)
+
+type bulkResponse struct {
+	Results []bulkResult   `json:"results"`
+	Summary map[string]int `json:"summary"`
+}
+
+func TestBulkSelector(t *testing.T) {
+	ts := setupServer(t)
+
+	billing := uploadCSV(sampleCSV, map[string]string{"processor": "test-slow", "labels": "team=billing,env=staging"}, ts, t)
+	prod := uploadCSV(sampleCSV, map[string]string{"processor": "test-slow", "labels": "team=billing,env=prod"}, ts, t)
+	other := uploadCSV(sampleCSV, map[string]string{"processor": "test-slow", "labels": "team=search"}, ts, t)
+	finished := uploadCSV(sampleCSV, map[string]string{"processor": "test-counter", "labels": "team=billing"}, ts, t)
+
+	time.Sleep(100 * time.Millisecond)
+
+	checkStatus(finished, task.TaskFinished, ts, t)
+
+	var res bulkResponse
+	body := map[string]string{"selector": "team=billing,env!=prod", "actor": "ops", "wait": "2s"}
+	if resp := v1Request(http.MethodPost, "/tasks:pause", body, &res, ts, t); resp.StatusCode != http.StatusOK {
+		t.Fatalf("bad status: %s", resp.Status)
+	}
+
+	if len(res.Results) != 2 || res.Summary[bulkApplied] != 1 || res.Summary[bulkSkipped] != 1 {
+		t.Fatalf("incorrect results: %+v", res)
+	}
+	for _, r := range res.Results {
+		switch r.ID {
+		case billing:
+			if r.Result != bulkApplied || r.Status != task.TaskPaused {
+				t.Errorf("incorrect result of the running task: %+v", r)
+			}
+		case finished:
+			if r.Result != bulkSkipped || r.Status != task.TaskFinished || r.Message == "" {
+				t.Errorf("incorrect result of the finished task: %+v", r)
+			}
+		default:
+			t.Errorf("unexpected task: %+v", r)
+		}
+	}
+
+	checkStatus(prod, task.TaskRunning, ts, t)
+	checkStatus(other, task.TaskRunning, ts, t)
+}
+
+func TestBulkIDs(t *testing.T) {
+	ts := setupServer(t)
+
+	a := uploadCSV(sampleCSV, map[string]string{"processor": "test-slow"}, ts, t)
+	b := uploadCSV(sampleCSV, map[string]string{"processor": "test-slow"}, ts, t)
+
+	var res bulkResponse
+	body := map[string]interface{}{"ids": []string{a, "does-not-exist", b, a}, "wait": "2s"}
+	v1Request(http.MethodPost, "/tasks:terminate", body, &res, ts, t)
+
+	if len(res.Results) != 3 || res.Summary[bulkApplied] != 2 || res.Summary[bulkNotFound] != 1 {
+		t.Fatalf("incorrect results: %+v", res)
+	}
+	if r := res.Results[1]; r.ID != "does-not-exist" || r.Result != bulkNotFound {
+		t.Fatalf("incorrect result of the unknown task: %+v", r)
+	}
+	checkStatus(a, task.TaskTerminated, ts, t)
+	checkStatus(b, task.TaskTerminated, ts, t)
+}
+
+func TestBulkBlankSelector(t *testing.T) {
+	ts := setupServer(t)
+
+	id := uploadCSV(sampleCSV, map[string]string{"processor": "test-slow"}, ts, t)
+
+	for _, selector := range []string{" ", "\t"} {
+		body := map[string]interface{}{"selector": selector}
+		if resp := v1Request(http.MethodPost, "/tasks:terminate", body, nil, ts, t); resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("bad status for selector %q: %s", selector, resp.Status)
+		}
+	}
+	checkStatus(id, task.TaskRunning, ts, t)
+}
+
+func TestBulkErrors(t *testing.T) {
+	ts := setupServer(t)
+
+	tests := []struct {
+		method, path string
+		body         interface{}
+		code         int
+	}{
+		{http.MethodGet, "/tasks:pause", nil, http.StatusMethodNotAllowed},
+		{http.MethodPost, "/tasks:pause", map[string]interface{}{}, http.StatusBadRequest},
+		{http.MethodPost, "/tasks:pause", map[string]interface{}{"ids": []string{"a"}, "selector": "team=billing"}, http.StatusBadRequest},
+		{http.MethodPost, "/tasks:pause", map[string]interface{}{"selector": "team"}, http.StatusBadRequest},
+		{http.MethodPost, "/tasks:pause", map[string]interface{}{"selector": " "}, http.StatusBadRequest},
+		{http.MethodPost, "/tasks:resume", map[string]interface{}{"ids": []string{"a"}, "wait": "soon"}, http.StatusBadRequest},
+	}
+
+	for _, tc := range tests {
+		if resp := v1Request(tc.method, tc.path, tc.body, nil, ts, t); resp.StatusCode != tc.code {
+			t.Errorf("%s %s %v: expected %d, got: %s", tc.method, tc.path, tc.body, tc.code, resp.Status)
+		}
+	}
+}
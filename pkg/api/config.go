@@ -0,0 +1,167 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/prmsrswt/pipeline/pkg/store"
+	"github.com/prmsrswt/pipeline/pkg/task"
+)
+
+// configV1 is the JSON form of the options of a task, written like the upload
+// form values: characters are strings and durations are strings such as "90s".
+// The checkpoint interval is set by the server, so it is left out.
+type configV1 struct {
+	Processor   string               `json:"processor"`
+	Timeout     string               `json:"timeout,omitempty"`
+	Deadline    time.Time            `json:"deadline"`
+	StartAt     time.Time            `json:"startAt"`
+	Output      string               `json:"output"`
+	Dialect     dialectV1            `json:"dialect"`
+	Malformed   task.MalformedPolicy `json:"malformed"`
+	ErrorBudget float64              `json:"errorBudget"`
+	Retry       retryV1              `json:"retry"`
+	Range       task.Range           `json:"range"`
+	Breakpoints []task.Breakpoint    `json:"breakpoints,omitempty"`
+}
+
+// dialectV1 is the JSON form of task.Dialect.
+type dialectV1 struct {
+	Delimiter        string `json:"delimiter,omitempty"`
+	Comment          string `json:"comment,omitempty"`
+	LazyQuotes       bool   `json:"lazyQuotes,omitempty"`
+	TrimLeadingSpace bool   `json:"trimLeadingSpace,omitempty"`
+	FieldsPerRecord  int    `json:"fieldsPerRecord,omitempty"`
+	Header           bool   `json:"header,omitempty"`
+}
+
+// retryV1 is the JSON form of task.RetryPolicy.
+type retryV1 struct {
+	MaxAttempts int    `json:"maxAttempts,omitempty"`
+	Backoff     string `json:"backoff,omitempty"`
+	MaxBackoff  string `json:"maxBackoff,omitempty"`
+}
+
+// newConfigV1 returns the JSON form of the config.
+func newConfigV1(cfg task.Config) configV1 {
+	return configV1{
+		Processor: cfg.Processor,
+		Timeout:   formatDuration(cfg.Timeout),
+		Deadline:  cfg.Deadline,
+		StartAt:   cfg.StartAt,
+		Output:    cfg.Output,
+		Dialect: dialectV1{
+			Delimiter:        formatRune(cfg.Dialect.Delimiter),
+			Comment:          formatRune(cfg.Dialect.Comment),
+			LazyQuotes:       cfg.Dialect.LazyQuotes,
+			TrimLeadingSpace: cfg.Dialect.TrimLeadingSpace,
+			FieldsPerRecord:  cfg.Dialect.FieldsPerRecord,
+			Header:           cfg.Dialect.Header,
+		},
+		Malformed:   cfg.Malformed,
+		ErrorBudget: cfg.ErrorBudget,
+		Retry: retryV1{
+			MaxAttempts: cfg.Retry.MaxAttempts,
+			Backoff:     formatDuration(cfg.Retry.Backoff),
+			MaxBackoff:  formatDuration(cfg.Retry.MaxBackoff),
+		},
+		Range:       cfg.Range,
+		Breakpoints: cfg.Breakpoints,
+	}
+}
+
+// config returns the config of the JSON form, with the same errors as the
+// upload form values.
+func (c configV1) config() (task.Config, error) {
+	cfg := task.Config{
+		Processor:   c.Processor,
+		Deadline:    c.Deadline,
+		StartAt:     c.StartAt,
+		Output:      c.Output,
+		Malformed:   c.Malformed,
+		ErrorBudget: c.ErrorBudget,
+		Range:       c.Range,
+		Breakpoints: c.Breakpoints,
+		Dialect: task.Dialect{
+			LazyQuotes:       c.Dialect.LazyQuotes,
+			TrimLeadingSpace: c.Dialect.TrimLeadingSpace,
+			FieldsPerRecord:  c.Dialect.FieldsPerRecord,
+			Header:           c.Dialect.Header,
+		},
+		Retry: task.RetryPolicy{MaxAttempts: c.Retry.MaxAttempts},
+	}
+
+	var err error
+	if c.Timeout != "" {
+		cfg.Timeout, err = time.ParseDuration(c.Timeout)
+		if err != nil || cfg.Timeout <= 0 {
+			return cfg, errors.New("invalid timeout")
+		}
+	}
+
+	if cfg.Dialect.Delimiter, err = parseRune(c.Dialect.Delimiter); err != nil {
+		return cfg, fmt.Errorf("invalid delimiter: %w", err)
+	}
+	if cfg.Dialect.Comment, err = parseRune(c.Dialect.Comment); err != nil {
+		return cfg, fmt.Errorf("invalid comment: %w", err)
+	}
+
+	for name, field := range map[string]struct {
+		v string
+		d *time.Duration
+	}{
+		"backoff":    {c.Retry.Backoff, &cfg.Retry.Backoff},
+		"maxBackoff": {c.Retry.MaxBackoff, &cfg.Retry.MaxBackoff},
+	} {
+		if field.v != "" {
+			if *field.d, err = time.ParseDuration(field.v); err != nil {
+				return cfg, fmt.Errorf("invalid retry %s", name)
+			}
+		}
+	}
+
+	return cfg, nil
+}
+
+// formatDuration returns the duration as parsed by time.ParseDuration, or an
+// empty string if it is zero.
+func formatDuration(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+	return d.String()
+}
+
+// formatRune returns the character as parsed by parseRune, or an empty string
+// if it is zero.
+func formatRune(r rune) string {
+	if r == 0 {
+		return ""
+	}
+	return string(r)
+}
+
+// snapshotV1 is the JSON form of a snapshot, with the config as configV1.
+type snapshotV1 struct {
+	task.Snapshot
+	Config configV1 `json:"config"`
+}
+
+func newSnapshotV1(s task.Snapshot) snapshotV1 {
+	return snapshotV1{Snapshot: s, Config: newConfigV1(s.Config)}
+}
+
+// pageV1 is the JSON form of a page of search results.
+type pageV1 struct {
+	Tasks      []snapshotV1 `json:"tasks"`
+	NextCursor string       `json:"nextCursor,omitempty"`
+}
+
+func newPageV1(page store.Page) pageV1 {
+	p := pageV1{Tasks: make([]snapshotV1, 0, len(page.Tasks)), NextCursor: page.NextCursor}
+	for _, s := range page.Tasks {
+		p.Tasks = append(p.Tasks, newSnapshotV1(s))
+	}
+	return p
+}
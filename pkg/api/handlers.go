@@ -1,12 +1,22 @@
 package api
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
 	"io"
 	"log"
 	"net/http"
 	"os"
 	"path"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/prmsrswt/pipeline/pkg/store"
 	"github.com/prmsrswt/pipeline/pkg/task"
 
 	"github.com/google/uuid"
@@ -23,31 +33,160 @@ func (a *API) handleUpload(w http.ResponseWriter, r *http.Request) {
 	}
 	defer file.Close()
 
+	cfg := task.Config{
+		Processor: r.FormValue("processor"),
+		Output:    r.FormValue("output"),
+		Malformed: task.MalformedPolicy(r.FormValue("malformed")),
+	}
+
+	if v := r.FormValue("timeout"); v != "" {
+		cfg.Timeout, err = time.ParseDuration(v)
+		if err != nil || cfg.Timeout <= 0 {
+			respondError(w, "invalid timeout", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if v := r.FormValue("deadline"); v != "" {
+		cfg.Deadline, err = time.Parse(time.RFC3339, v)
+		if err != nil {
+			respondError(w, "invalid deadline", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if v := r.FormValue("startAt"); v != "" {
+		cfg.StartAt, err = time.Parse(time.RFC3339, v)
+		if err != nil {
+			respondError(w, "invalid startAt", http.StatusBadRequest)
+			return
+		}
+	}
+
+	start := true
+	if v := r.FormValue("start"); v != "" {
+		start, err = strconv.ParseBool(v)
+		if err != nil {
+			respondError(w, "invalid start", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if v := r.FormValue("errorBudget"); v != "" {
+		cfg.ErrorBudget, err = strconv.ParseFloat(v, 64)
+		if err != nil {
+			respondError(w, "invalid errorBudget", http.StatusBadRequest)
+			return
+		}
+	}
+
+	cfg.Dialect, err = parseDialect(r)
+	if err != nil {
+		respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	cfg.Retry, err = parseRetry(r)
+	if err != nil {
+		respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	cfg.Range, err = parseRange(r)
+	if err != nil {
+		respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	cfg.Breakpoints, err = parseBreakpoints(r.Form["breakpoint"])
+	if err != nil {
+		respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	labels, err := parseLabels(r.FormValue("labels"))
+	if err != nil {
+		respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	meta := task.Metadata{
+		Filename:    handler.Filename,
+		ContentType: handler.Header.Get("Content-Type"),
+		Labels:      labels,
+	}
+	t := a.createTask(w, meta, cfg, file, requestCause(r), start)
+	if t == nil {
+		return
+	}
+	respondSuccess(w, map[string]string{"id": t.ID})
+
+	log.Println("[success] file uploaded: ", handler.Filename)
+}
+
+// createTask saves the uploaded file of a new task, then stores the task and
+// submits it if start is set. Tasks with a StartAt time are submitted then.
+// If any of it fails, it responds with the error and returns nil.
+// The size and checksum of the file are added to the metadata, along with
+// the actor of the cause as the uploader.
+func (a *API) createTask(w http.ResponseWriter, meta task.Metadata, cfg task.Config, src io.Reader, cause task.Cause, start bool) *task.Task {
 	id := uuid.New().String()
+	filePath := path.Join(a.uploadDir, id+path.Base(meta.Filename))
+	cfg.CheckpointInterval = a.checkpointInterval
+
+	t, err := task.NewTask(id, filePath, cfg)
+	if err != nil {
+		respondError(w, err.Error(), http.StatusBadRequest)
+		return nil
+	}
+	if err := task.ValidateLabels(meta.Labels); err != nil {
+		respondError(w, err.Error(), http.StatusBadRequest)
+		return nil
+	}
 
 	// Create a file locally
-	filePath := path.Join(a.uploadDir, id+handler.Filename)
 	dst, err := os.Create(filePath)
 	if err != nil {
 		respondError(w, "error creating file", http.StatusInternalServerError)
 		log.Println("[error] creating file: ", err)
-		return
+		return nil
 	}
 	defer dst.Close()
 
-	if _, err := io.Copy(dst, file); err != nil {
+	d := newDigest()
+	if _, err := io.Copy(io.MultiWriter(dst, d), src); err != nil {
+		a.discardTask(t)
 		respondError(w, "error saving file", http.StatusInternalServerError)
 		log.Println("[error] saving file: ", err)
-		return
+		return nil
 	}
 
-	t := task.NewTask(id, filePath)
-	a.taskStore[t.ID] = t
+	meta.Size, meta.SHA256, meta.Uploader = d.size, d.sum(), cause.Actor
+	if err := t.SetMetadata(meta); err != nil {
+		a.discardTask(t)
+		respondError(w, err.Error(), http.StatusBadRequest)
+		return nil
+	}
 
-	t.Run()
-	respondSuccess(w, map[string]string{"id": t.ID})
+	if err := a.store.Put(t); err != nil {
+		a.discardTask(t)
+		respondError(w, "error storing task", http.StatusInternalServerError)
+		log.Println("[error] storing task: ", err)
+		return nil
+	}
 
-	log.Println("[success] file uploaded: ", handler.Filename)
+	switch {
+	case !cfg.StartAt.IsZero():
+		a.scheduleStart(t)
+	case start:
+		if err := a.scheduler.Submit(t, cause); err != nil {
+			a.discardTask(t)
+			respondError(w, "error starting task", http.StatusInternalServerError)
+			log.Println("[error] starting task: ", err)
+			return nil
+		}
+	}
+	return t
 }
 
 func (a *API) handleStatus(w http.ResponseWriter, r *http.Request) {
@@ -57,38 +196,363 @@ func (a *API) handleStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	respondSuccess(w, map[string]task.Status{"status": t.State})
+	respondSuccess(w, newSnapshotV1(t.Snapshot()))
 }
 
-func (a *API) handlePause(w http.ResponseWriter, r *http.Request) {
-	t, ok := a.getTaskFromReq(r)
-	if !ok {
-		respondError(w, "invalid task id", http.StatusBadRequest)
+func (a *API) handleTask(w http.ResponseWriter, r *http.Request) {
+	// Resources of a task are served at /tasks/{id}/{resource}.
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/tasks/"), "/")
+	if len(parts) != 2 || !taskResources[parts[1]] {
+		respondError(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	t, err := a.store.Get(parts[0])
+	if err != nil {
+		respondError(w, "task not found", http.StatusNotFound)
 		return
 	}
 
-	t.Pause()
-	respondSuccess(w, map[string]string{"message": "task paused"})
+	a.serveResource(w, r, t, parts[1])
 }
 
-func (a *API) handleResume(w http.ResponseWriter, r *http.Request) {
-	t, ok := a.getTaskFromReq(r)
-	if !ok {
-		respondError(w, "invalid task id", http.StatusBadRequest)
+// serveResource serves one of the taskResources of the task.
+func (a *API) serveResource(w http.ResponseWriter, r *http.Request, t *task.Task, resource string) {
+	switch resource {
+	case "events":
+		respondSuccess(w, map[string]interface{}{
+			"events":   t.Events(),
+			"timeline": t.Timeline(),
+		})
+	case "output":
+		a.handleOutput(w, r, t)
+	case "quarantine":
+		a.handleQuarantine(w, r, t)
+	case "replay":
+		a.handleReplay(w, r, t)
+	case "preview":
+		a.handlePreview(w, r, t)
+	case "rerun":
+		a.handleRerun(w, r, t)
+	}
+}
+
+// taskResources lists the resources served at /tasks/{id}/{resource}.
+var taskResources = map[string]bool{
+	"events":     true,
+	"output":     true,
+	"quarantine": true,
+	"replay":     true,
+	"preview":    true,
+	"rerun":      true,
+}
+
+// outputTypes maps the output formats to their content type.
+var outputTypes = map[string]string{
+	task.OutputCSV:   "text/csv",
+	task.OutputJSONL: "application/x-ndjson",
+}
+
+// handleOutput streams the output file of a finished task, or what has been
+// written so far if the task is paused.
+func (a *API) handleOutput(w http.ResponseWriter, r *http.Request, t *task.Task) {
+	status := t.Status()
+	if status != task.TaskFinished && status != task.TaskPaused {
+		respondConflict(w, "output is only available once the task is finished or paused", status)
 		return
 	}
 
-	t.Resume()
-	respondSuccess(w, map[string]string{"message": "task resumed"})
+	w.Header().Set("X-Output-Partial", strconv.FormatBool(status != task.TaskFinished))
+	serveFile(w, r, t.OutputPath(), t.OutputSize(), outputTypes[t.Config.Output])
+}
+
+// handleQuarantine streams the quarantine file of a task which is stopped or paused.
+func (a *API) handleQuarantine(w http.ResponseWriter, r *http.Request, t *task.Task) {
+	status := t.Status()
+	if !replayable(status) {
+		respondConflict(w, "quarantine is only available once the task is stopped or paused", status)
+		return
+	}
+
+	if t.QuarantineSize() == 0 {
+		respondError(w, "task has no quarantine", http.StatusNotFound)
+		return
+	}
+	serveFile(w, r, t.QuarantinePath(), t.QuarantineSize(), "text/csv")
+}
+
+// replayable reports whether the quarantine of a task in this status can be read.
+func replayable(status task.Status) bool {
+	switch status {
+	case task.TaskPaused, task.TaskFinished, task.TaskGotError, task.TaskTerminated, task.TaskTimedOut:
+		return true
+	}
+	return false
+}
+
+// handlePreview responds with the first records of the file of the task as
+// they get processed, also running the processor on them if dryRun is set.
+func (a *API) handlePreview(w http.ResponseWriter, r *http.Request, t *task.Task) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, http.MethodGet)
+		return
+	}
+
+	n := task.DefaultPreviewRecords
+	if v := r.FormValue("records"); v != "" {
+		var err error
+		n, err = strconv.Atoi(v)
+		if err != nil || n <= 0 || n > task.MaxPreviewRecords {
+			respondError(w, fmt.Sprintf("records must be between 1 and %d", task.MaxPreviewRecords), http.StatusBadRequest)
+			return
+		}
+	}
+
+	var dryRun bool
+	if v := r.FormValue("dryRun"); v != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(v)
+		if err != nil {
+			respondError(w, "invalid dryRun", http.StatusBadRequest)
+			return
+		}
+	}
+
+	preview, err := t.Preview(r.Context(), n, dryRun)
+	if err != nil {
+		respondError(w, "error previewing task", http.StatusInternalServerError)
+		log.Println("[error] previewing task: ", err)
+		return
+	}
+	respondSuccess(w, preview)
+}
+
+// handleReplay creates a task processing only the quarantined records of the
+// requested one, optionally with another processor.
+func (a *API) handleReplay(w http.ResponseWriter, r *http.Request, parent *task.Task) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, http.MethodPost)
+		return
+	}
+
+	status := parent.Status()
+	if !replayable(status) {
+		respondConflict(w, "quarantine can only be replayed once the task is stopped or paused", status)
+		return
+	}
+
+	// The deadline was meant for the original task, the timeout still applies,
+	// and the range and row breakpoints for its file, as the replay numbers its
+	// rows anew.
+	cfg := parent.Config
+	cfg.Deadline = time.Time{}
+	cfg.Range = task.Range{}
+	cfg.Breakpoints = nil
+	for _, bp := range parent.Config.Breakpoints {
+		if bp.Row == 0 {
+			cfg.Breakpoints = append(cfg.Breakpoints, bp)
+		}
+	}
+	if p := r.FormValue("processor"); p != "" {
+		cfg.Processor = p
+	}
+
+	id := uuid.New().String()
+	filePath := path.Join(a.uploadDir, id+"replay.csv")
+
+	t, err := task.NewTask(id, filePath, cfg)
+	if err != nil {
+		respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	dst, err := os.Create(filePath)
+	if err != nil {
+		respondError(w, "error creating file", http.StatusInternalServerError)
+		log.Println("[error] creating file: ", err)
+		return
+	}
+	defer dst.Close()
+
+	d := newDigest()
+	records, err := parent.WriteReplay(io.MultiWriter(dst, d))
+	if err != nil {
+		removeFiles(t)
+		if errors.Is(err, task.ErrNothingQuarantined) {
+			respondConflict(w, "task has no quarantined records", status)
+			return
+		}
+
+		respondError(w, "error saving file", http.StatusInternalServerError)
+		log.Println("[error] saving file: ", err)
+		return
+	}
+
+	cause := requestCause(r)
+	if cause.Reason == "" {
+		cause.Reason = "replay of " + parent.ID
+	}
+
+	// The replay keeps the name and labels of the original file.
+	meta := parent.Metadata()
+	meta.Size, meta.SHA256, meta.ContentType, meta.Uploader = d.size, d.sum(), "text/csv", cause.Actor
+	meta.Parent = parent.ID
+	if err := t.SetMetadata(meta); err != nil {
+		a.discardTask(t)
+		respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := a.store.Put(t); err != nil {
+		a.discardTask(t)
+		respondError(w, "error storing task", http.StatusInternalServerError)
+		log.Println("[error] storing task: ", err)
+		return
+	}
+
+	if err := a.scheduler.Submit(t, cause); err != nil {
+		a.discardTask(t)
+		respondError(w, "error starting task", http.StatusInternalServerError)
+		log.Println("[error] starting task: ", err)
+		return
+	}
+	respondSuccess(w, map[string]interface{}{"id": t.ID, "records": records})
+
+	log.Printf("[success] replaying %d records of %s as %s\n", records, parent.ID, t.ID)
+}
+
+// discardTask removes a task which couldn't be created from the store, if it
+// got there, along with its files, so that none are left without a task.
+func (a *API) discardTask(t *task.Task) {
+	if err := a.store.Delete(t.ID); err != nil && !errors.Is(err, store.ErrNotFound) {
+		log.Printf("[%s] deleting discarded task: %v\n", t.ID, err)
+	}
+	removeFiles(t)
+}
+
+// removeFiles removes the files kept for the task.
+func removeFiles(t *task.Task) {
+	for _, p := range t.Files() {
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			log.Printf("[%s] removing %s: %v\n", t.ID, p, err)
+		}
+	}
+}
+
+// digest computes the size and SHA-256 checksum of what is written to it.
+type digest struct {
+	hash hash.Hash
+	size int64
+}
+
+func newDigest() *digest {
+	return &digest{hash: sha256.New()}
+}
+
+func (d *digest) Write(p []byte) (int, error) {
+	d.hash.Write(p)
+	d.size += int64(len(p))
+	return len(p), nil
+}
+
+// sum returns the hex encoded checksum.
+func (d *digest) sum() string {
+	return hex.EncodeToString(d.hash.Sum(nil))
+}
+
+// serveFile streams the first size bytes of the file at path. The file may
+// still grow as its task goes on, but only complete records are sent.
+func serveFile(w http.ResponseWriter, r *http.Request, filePath string, size int64, contentType string) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		respondError(w, "error opening file", http.StatusInternalServerError)
+		log.Println("[error] opening file: ", err)
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		respondError(w, "error opening file", http.StatusInternalServerError)
+		log.Println("[error] opening file: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", path.Base(filePath)))
+	http.ServeContent(w, r, "", info.ModTime(), io.NewSectionReader(file, 0, size))
+}
+
+func (a *API) handleStart(w http.ResponseWriter, r *http.Request) {
+	a.handleControl(w, r, a.scheduler.Submit, "task start requested")
+}
+
+func (a *API) handlePause(w http.ResponseWriter, r *http.Request) {
+	a.handleControl(w, r, (*task.Task).Pause, "task pause requested")
+}
+
+func (a *API) handleResume(w http.ResponseWriter, r *http.Request) {
+	a.handleControl(w, r, (*task.Task).Resume, "task resumed")
+}
+
+func (a *API) handleStep(w http.ResponseWriter, r *http.Request) {
+	a.handleControl(w, r, (*task.Task).Step, "task step requested")
 }
 
 func (a *API) handleTerminate(w http.ResponseWriter, r *http.Request) {
+	a.handleControl(w, r, (*task.Task).Terminate, "task termination requested")
+}
+
+// handleControl applies the control operation to the task requested in the form values.
+func (a *API) handleControl(w http.ResponseWriter, r *http.Request, op func(*task.Task, task.Cause) error, message string) {
 	t, ok := a.getTaskFromReq(r)
 	if !ok {
 		respondError(w, "invalid task id", http.StatusBadRequest)
 		return
 	}
 
-	t.Terminate()
-	respondSuccess(w, map[string]string{"message": "task terminated"})
+	wait, err := parseWait(r.FormValue("wait"))
+	if err != nil {
+		respondError(w, "invalid wait duration", http.StatusBadRequest)
+		return
+	}
+
+	a.control(w, r, t, op, requestCause(r), wait, message)
+}
+
+// control applies the control operation to the task and responds with its
+// status, optionally waiting for the worker to apply it.
+func (a *API) control(w http.ResponseWriter, r *http.Request, t *task.Task, op func(*task.Task, task.Cause) error, cause task.Cause, wait time.Duration, message string) {
+	if err := op(t, cause); err != nil {
+		var terr *task.TransitionError
+		if errors.As(err, &terr) {
+			respondConflict(w, terr.Error(), terr.Status)
+			return
+		}
+
+		respondError(w, "error controlling task", http.StatusInternalServerError)
+		log.Println("[error] controlling task: ", err)
+		return
+	}
+
+	waitSettled(r.Context(), t, wait)
+
+	respondSuccess(w, map[string]interface{}{
+		"message": message,
+		"status":  t.Status(),
+	})
+}
+
+// waitSettled waits at most wait for the worker of the task to apply the
+// requested change, unless the context is done first.
+func waitSettled(ctx context.Context, t *task.Task, wait time.Duration) {
+	if wait <= 0 {
+		return
+	}
+
+	select {
+	case <-t.Settled():
+	case <-time.After(wait):
+	case <-ctx.Done():
+	}
 }
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/prmsrswt/pipeline/pkg/task"
+)
+
+// rerunRequest is the optional body of a request re-running a task.
+type rerunRequest struct {
+	// Config overrides options of the parent task, the ones left out are kept.
+	Config json.RawMessage `json:"config"`
+	// Labels replace the labels of the parent task, unless nil.
+	Labels map[string]string `json:"labels"`
+	// Start submits the task right away, it defaults to true.
+	Start  *bool  `json:"start"`
+	Actor  string `json:"actor"`
+	Reason string `json:"reason"`
+}
+
+// rerunnable reports whether a task in this status is done running, so that
+// it can be run again.
+func rerunnable(status task.Status) bool {
+	switch status {
+	case task.TaskFinished, task.TaskGotError, task.TaskTerminated, task.TaskTimedOut:
+		return true
+	}
+	return false
+}
+
+// handleRerun creates a task processing the uploaded file of a stopped task
+// again, with the same options unless overridden, and responds with its snapshot.
+func (a *API) handleRerun(w http.ResponseWriter, r *http.Request, parent *task.Task) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, http.MethodPost)
+		return
+	}
+
+	status := parent.Status()
+	if !rerunnable(status) {
+		respondConflict(w, "only finished, failed, terminated or timed out tasks can be re-run", status)
+		return
+	}
+
+	var req rerunRequest
+	if err := decodeBody(w, r, &req); err != nil {
+		respondBodyError(w, err)
+		return
+	}
+
+	// The deadline and start time were meant for the original task.
+	overridden := newConfigV1(parent.Config)
+	overridden.Deadline, overridden.StartAt = time.Time{}, time.Time{}
+	if req.Config != nil {
+		dec := json.NewDecoder(bytes.NewReader(req.Config))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&overridden); err != nil {
+			respondError(w, "invalid config: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	cfg, err := overridden.config()
+	if err != nil {
+		respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	file, err := os.Open(parent.FilePath)
+	if err != nil {
+		respondError(w, "error reading file", http.StatusInternalServerError)
+		log.Println("[error] reading file: ", err)
+		return
+	}
+	defer file.Close()
+
+	cause := clientCause(r, req.Actor, req.Reason)
+	if cause.Reason == "" {
+		cause.Reason = "re-run of " + parent.ID
+	}
+
+	meta := parent.Metadata()
+	meta.Parent = parent.ID
+	if req.Labels != nil {
+		meta.Labels = req.Labels
+	}
+
+	// The task is started once it has the offset index of the file, which
+	// lets it skip to its range right away.
+	t := a.createTask(w, meta, cfg, file, cause, false)
+	if t == nil {
+		return
+	}
+	if err := copyFile(t.IndexPath(), parent.IndexPath()); err != nil && !os.IsNotExist(err) {
+		log.Printf("[%s] copying offset index: %v\n", t.ID, err)
+	}
+
+	if (req.Start == nil || *req.Start) && cfg.StartAt.IsZero() {
+		if err := a.scheduler.Submit(t, cause); err != nil {
+			a.discardTask(t)
+			respondError(w, "error starting task", http.StatusInternalServerError)
+			log.Println("[error] starting task: ", err)
+			return
+		}
+	}
+
+	w.Header().Set("Location", v1Prefix+"/tasks/"+t.ID)
+	respond(w, response{Status: "success", Data: newSnapshotV1(t.Snapshot())}, http.StatusCreated)
+
+	log.Printf("[success] re-running %s as %s\n", parent.ID, t.ID)
+}
+
+// copyFile copies the file at src to dst.
+func copyFile(dst, src string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -2,32 +2,144 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"log"
+	"net"
 	"net/http"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/prmsrswt/pipeline/pkg/store"
 	"github.com/prmsrswt/pipeline/pkg/task"
 )
 
+// Options holds the configuration of the API.
+type Options struct {
+	// UploadDir is the directory where uploaded files and their checkpoints are kept.
+	UploadDir string
+	// CheckpointInterval is how often running tasks persist their progress.
+	CheckpointInterval time.Duration
+	// Concurrency is the maximum number of tasks running at once, zero means no limit.
+	Concurrency int
+	// ReleasePaused makes paused tasks free their slot for queued ones.
+	ReleasePaused bool
+	// Store keeps the tasks, they are kept in memory if it is nil.
+	Store store.TaskStore
+}
+
 // API represents the http API.
 type API struct {
-	taskStore map[string]*task.Task
+	store     store.TaskStore
+	scheduler *task.Scheduler
+	controls  map[string]control
 	uploadDir string
+
+	checkpointInterval time.Duration
 }
 
 // NewAPI returns an initialized instance of API.
-func NewAPI(uploadDir string) *API {
+func NewAPI(opts Options) *API {
+	s := opts.Store
+	if s == nil {
+		s = store.NewMemoryStore()
+	}
+
+	sched := task.NewScheduler(opts.Concurrency, opts.ReleasePaused)
 	return &API{
-		taskStore: make(map[string]*task.Task),
-		uploadDir: uploadDir,
+		store:              s,
+		scheduler:          sched,
+		controls:           newControls(sched),
+		uploadDir:          opts.UploadDir,
+		checkpointInterval: opts.CheckpointInterval,
+	}
+}
+
+// Restore rebuilds the tasks of the store if it is durable, then the ones
+// from the checkpoints found in the upload directory which it doesn't know of.
+// Tasks which can't be restored are skipped.
+func (a *API) Restore() error {
+	if r, ok := a.store.(store.Restorer); ok {
+		if err := r.Restore(a.scheduler); err != nil {
+			return err
+		}
+	}
+
+	paths, err := filepath.Glob(path.Join(a.uploadDir, "*"+task.CheckpointExt))
+	if err != nil {
+		return err
+	}
+
+	for _, p := range paths {
+		cp, err := task.ReadCheckpoint(p)
+		if err != nil {
+			log.Printf("[error] restoring task from %s: %v\n", p, err)
+			continue
+		}
+		if _, err := a.store.Get(cp.ID); err == nil {
+			continue
+		}
+
+		t, err := task.RestoreCheckpoint(cp, a.scheduler)
+		if err != nil {
+			log.Printf("[error] restoring task from %s: %v\n", p, err)
+			continue
+		}
+		if err := a.store.Put(t); err != nil {
+			return err
+		}
+	}
+
+	tasks, err := a.store.List()
+	if err != nil {
+		return err
+	}
+	for _, t := range tasks {
+		if t.Status() == task.TaskNotStarted && !t.Config.StartAt.IsZero() {
+			a.scheduleStart(t)
+		}
 	}
+
+	return nil
+}
+
+// scheduleStart submits the task at its StartAt time, unless it got started
+// or deleted by then.
+func (a *API) scheduleStart(t *task.Task) {
+	time.AfterFunc(time.Until(t.Config.StartAt), func() {
+		if stored, err := a.store.Get(t.ID); err != nil || stored != t {
+			return
+		}
+
+		cause := task.Cause{Actor: task.SystemActor, Reason: "scheduled start"}
+		if err := a.scheduler.Submit(t, cause); err != nil && !errors.Is(err, task.ErrInvalidTransition) {
+			log.Printf("[%s] scheduled start: %v\n", t.ID, err)
+		}
+	})
+
+	log.Printf("[%s] scheduled to start at %s\n", t.ID, t.Config.StartAt.Format(time.RFC3339))
 }
 
 // Register function registers the routes and handlers.
 func (a *API) Register(mux *http.ServeMux) {
 	mux.HandleFunc("/upload", a.handleUpload)
 	mux.HandleFunc("/status", a.handleStatus)
+	mux.HandleFunc("/start", a.handleStart)
 	mux.HandleFunc("/pause", a.handlePause)
 	mux.HandleFunc("/resume", a.handleResume)
+	mux.HandleFunc("/step", a.handleStep)
 	mux.HandleFunc("/terminate", a.handleTerminate)
+	mux.HandleFunc("/tasks/", a.handleTask)
+	mux.Handle("/debug/vars", expvar.Handler())
+	mux.HandleFunc(v1Prefix+"/tasks", a.handleTasksV1)
+	mux.HandleFunc(v1Prefix+"/tasks/", a.handleTaskV1)
+	for action := range a.controls {
+		mux.HandleFunc(v1Prefix+"/tasks:"+action, a.handleBulk)
+	}
 }
 
 type response struct {
@@ -50,16 +162,202 @@ func respondError(w http.ResponseWriter, message string, code int) {
 	respond(w, response{Status: "error", Data: map[string]string{"message": message}}, code)
 }
 
+// respondConflict tells the client that the task is in a status which doesn't allow the request.
+func respondConflict(w http.ResponseWriter, message string, status task.Status) {
+	respond(w, response{Status: "error", Data: map[string]interface{}{
+		"message": message,
+		"status":  status,
+	}}, http.StatusConflict)
+}
+
 func respondSuccess(w http.ResponseWriter, data interface{}) {
 	respond(w, response{Status: "success", Data: data}, http.StatusOK)
 }
 
+// methodNotAllowed tells the client which methods the resource supports.
+func methodNotAllowed(w http.ResponseWriter, allowed ...string) {
+	w.Header().Set("Allow", strings.Join(allowed, ", "))
+	respondError(w, "method not allowed", http.StatusMethodNotAllowed)
+}
+
+// maxWait is the longest a control request may wait for the task to settle.
+const maxWait = 30 * time.Second
+
+// parseWait returns the duration of the optional wait value, capped at maxWait.
+func parseWait(v string) (time.Duration, error) {
+	if v == "" {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, errors.New("negative wait duration")
+	}
+	if d > maxWait {
+		d = maxWait
+	}
+	return d, nil
+}
+
+// parseDialect returns the CSV dialect from the optional upload form values.
+func parseDialect(r *http.Request) (task.Dialect, error) {
+	var d task.Dialect
+	var err error
+
+	if d.Delimiter, err = parseRune(r.FormValue("delimiter")); err != nil {
+		return d, fmt.Errorf("invalid delimiter: %w", err)
+	}
+	if d.Comment, err = parseRune(r.FormValue("comment")); err != nil {
+		return d, fmt.Errorf("invalid comment: %w", err)
+	}
+
+	for name, field := range map[string]*bool{
+		"lazyQuotes":       &d.LazyQuotes,
+		"trimLeadingSpace": &d.TrimLeadingSpace,
+		"header":           &d.Header,
+	} {
+		if v := r.FormValue(name); v != "" {
+			if *field, err = strconv.ParseBool(v); err != nil {
+				return d, fmt.Errorf("invalid %s", name)
+			}
+		}
+	}
+
+	if v := r.FormValue("fieldsPerRecord"); v != "" {
+		if d.FieldsPerRecord, err = strconv.Atoi(v); err != nil {
+			return d, errors.New("invalid fieldsPerRecord")
+		}
+	}
+
+	return d, nil
+}
+
+// parseRetry returns the retry policy from the optional upload form values.
+func parseRetry(r *http.Request) (task.RetryPolicy, error) {
+	var p task.RetryPolicy
+	var err error
+
+	if v := r.FormValue("retryAttempts"); v != "" {
+		if p.MaxAttempts, err = strconv.Atoi(v); err != nil {
+			return p, errors.New("invalid retryAttempts")
+		}
+	}
+
+	for name, field := range map[string]*time.Duration{
+		"retryBackoff":    &p.Backoff,
+		"retryMaxBackoff": &p.MaxBackoff,
+	} {
+		if v := r.FormValue(name); v != "" {
+			if *field, err = time.ParseDuration(v); err != nil {
+				return p, fmt.Errorf("invalid %s", name)
+			}
+		}
+	}
+
+	return p, nil
+}
+
+// parseRange returns the range of the file to process from the form values.
+func parseRange(r *http.Request) (task.Range, error) {
+	var rg task.Range
+
+	for name, field := range map[string]*int64{
+		"startRow":    &rg.StartRow,
+		"endRow":      &rg.EndRow,
+		"startOffset": &rg.StartOffset,
+		"endOffset":   &rg.EndOffset,
+	} {
+		if v := r.FormValue(name); v != "" {
+			var err error
+			if *field, err = strconv.ParseInt(v, 10, 64); err != nil {
+				return rg, fmt.Errorf("invalid %s", name)
+			}
+		}
+	}
+
+	return rg, nil
+}
+
+// parseBreakpoints returns the breakpoints of the form values, each being
+// either the row to break at or a field=value pair.
+func parseBreakpoints(values []string) ([]task.Breakpoint, error) {
+	var bps []task.Breakpoint
+	for _, v := range values {
+		if kv := strings.SplitN(v, "=", 2); len(kv) == 2 {
+			bps = append(bps, task.Breakpoint{Field: strings.TrimSpace(kv[0]), Value: kv[1]})
+			continue
+		}
+		row, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid breakpoint %q, expected a row or field=value", v)
+		}
+		bps = append(bps, task.Breakpoint{Row: row})
+	}
+	return bps, nil
+}
+
+// parseLabels returns the labels of a comma separated list of key=value pairs.
+func parseLabels(v string) (map[string]string, error) {
+	if v == "" {
+		return nil, nil
+	}
+
+	labels := make(map[string]string)
+	for _, pair := range strings.Split(v, ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid label %q, expected key=value", pair)
+		}
+		labels[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	}
+	return labels, task.ValidateLabels(labels)
+}
+
+// parseRune returns the single character of v, which may also be a tab
+// written as \t. An empty value means the zero rune.
+func parseRune(v string) (rune, error) {
+	if v == `\t` {
+		return '\t', nil
+	}
+
+	runes := []rune(v)
+	switch len(runes) {
+	case 0:
+		return 0, nil
+	case 1:
+		return runes[0], nil
+	}
+	return 0, errors.New("must be a single character")
+}
+
+// requestCause returns the cause of a status change requested by the client
+// from the actor and reason form values.
+func requestCause(r *http.Request) task.Cause {
+	return clientCause(r, r.FormValue("actor"), r.FormValue("reason"))
+}
+
+// clientCause returns the cause of a status change requested by the client.
+// The actor defaults to the address of the client if it isn't provided.
+func clientCause(r *http.Request, actor, reason string) task.Cause {
+	if actor == "" {
+		actor = r.RemoteAddr
+		if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+			actor = host
+		}
+	}
+
+	return task.Cause{Actor: actor, Reason: reason}
+}
+
 func (a *API) getTaskFromReq(r *http.Request) (*task.Task, bool) {
 	taskID := r.FormValue("id")
 	if taskID == "" {
 		return nil, false
 	}
 
-	t, ok := a.taskStore[taskID]
-	return t, ok
+	t, err := a.store.Get(taskID)
+	return t, err == nil
 }
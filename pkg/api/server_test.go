@@ -2,7 +2,9 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"io/ioutil"
 	"mime/multipart"
@@ -10,9 +12,14 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/prmsrswt/pipeline/pkg/store"
 	"github.com/prmsrswt/pipeline/pkg/task"
 )
 
@@ -24,7 +31,78 @@ const (
 	maxProcessingSec = 2
 )
 
+// recordCounter is a processor which only counts the records it gets.
+type recordCounter struct {
+	mu      sync.Mutex
+	records int
+}
+
+// counters holds the recordCounter of every task by its ID.
+var counters sync.Map
+
+func (c *recordCounter) Init(_ context.Context, taskID string) error {
+	counters.Store(taskID, c)
+	return nil
+}
+
+func (c *recordCounter) Process(context.Context, []string) ([]string, error) {
+	c.mu.Lock()
+	c.records++
+	c.mu.Unlock()
+	return nil, nil
+}
+
+func (c *recordCounter) Flush() error { return nil }
+
+func (c *recordCounter) Close() error { return nil }
+
+func countedRecords(id string, t *testing.T) int {
+	v, ok := counters.Load(id)
+	if !ok {
+		t.Fatalf("no records counted for task %s", id)
+	}
+
+	c := v.(*recordCounter)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.records
+}
+
+// slowProcessor takes a fixed amount of time for every record, which it
+// outputs unchanged along with the header.
+type slowProcessor struct{}
+
+func (slowProcessor) Init(context.Context, string) error { return nil }
+
+func (slowProcessor) Header(_ context.Context, header task.Header) ([]string, error) {
+	return header, nil
+}
+
+func (slowProcessor) Process(ctx context.Context, record []string) ([]string, error) {
+	select {
+	case <-time.After(slowRecordDuration):
+		return record, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (slowProcessor) Flush() error { return nil }
+
+func (slowProcessor) Close() error { return nil }
+
+const slowRecordDuration = 200 * time.Millisecond
+
+func init() {
+	task.RegisterProcessor("test-counter", func() task.Processor { return &recordCounter{} })
+	task.RegisterProcessor("test-slow", func() task.Processor { return slowProcessor{} })
+}
+
 func constructFileUpload(csv string, t *testing.T) (bytes.Buffer, string) {
+	return constructFileUploadWithFields(csv, nil, t)
+}
+
+func constructFileUploadWithFields(csv string, fields map[string]string, t *testing.T) (bytes.Buffer, string) {
 	var b bytes.Buffer
 	w := multipart.NewWriter(&b)
 	fw, err := w.CreateFormFile("file", "test.csv")
@@ -33,6 +111,12 @@ func constructFileUpload(csv string, t *testing.T) (bytes.Buffer, string) {
 	}
 
 	fw.Write([]byte(csv))
+
+	for k, v := range fields {
+		if err := w.WriteField(k, v); err != nil {
+			t.Fatal(err)
+		}
+	}
 	w.Close()
 
 	return b, w.FormDataContentType()
@@ -99,7 +183,7 @@ func checkStatus(id string, status task.Status, ts *httptest.Server, t *testing.
 }
 
 func requestAndCheckStatus(id, path string, status task.Status, ts *httptest.Server, t *testing.T) {
-	resp, err := ts.Client().PostForm(ts.URL+path, url.Values{"id": []string{id}})
+	resp, err := ts.Client().PostForm(ts.URL+path, url.Values{"id": []string{id}, "wait": []string{"2s"}})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -113,7 +197,11 @@ func requestAndCheckStatus(id, path string, status task.Status, ts *httptest.Ser
 }
 
 func uploadSampleCSV(ts *httptest.Server, t *testing.T) string {
-	b, contentType := constructFileUpload(sampleCSV, t)
+	return uploadCSV(sampleCSV, nil, ts, t)
+}
+
+func uploadCSV(csv string, fields map[string]string, ts *httptest.Server, t *testing.T) string {
+	b, contentType := constructFileUploadWithFields(csv, fields, t)
 
 	resp, err := ts.Client().Post(ts.URL+"/upload", contentType, &b)
 	if err != nil {
@@ -133,21 +221,94 @@ func setupServer(t *testing.T) *httptest.Server {
 	if err != nil {
 		t.Fatal(err)
 	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	return setupServerWithDir(dir, t)
+}
 
+func setupServerWithDir(dir string, t *testing.T) *httptest.Server {
+	return setupServerWithStore(dir, nil, t)
+}
+
+func setupServerWithStore(dir string, s store.TaskStore, t *testing.T) *httptest.Server {
 	mux := http.NewServeMux()
 
-	api := NewAPI(dir)
+	api := NewAPI(Options{UploadDir: dir, Store: s})
+	if err := api.Restore(); err != nil {
+		t.Fatal(err)
+	}
 	api.Register(mux)
 
 	ts := httptest.NewServer(mux)
-	t.Cleanup(func() {
-		ts.Close()
-		os.RemoveAll(dir)
-	})
+	t.Cleanup(ts.Close)
 
 	return ts
 }
 
+// failingStore is a memory store whose Put fails once failing is set.
+type failingStore struct {
+	*store.MemoryStore
+	failing int32
+}
+
+func (s *failingStore) Put(t *task.Task) error {
+	if atomic.LoadInt32(&s.failing) == 1 {
+		return errors.New("store unavailable")
+	}
+	return s.MemoryStore.Put(t)
+}
+
+// listFiles returns the names of the files in the directory.
+func listFiles(dir string, t *testing.T) []string {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, info := range infos {
+		names = append(names, info.Name())
+	}
+	return names
+}
+
+func TestUploadStoreError(t *testing.T) {
+	dir := t.TempDir()
+	ts := setupServerWithStore(dir, &failingStore{MemoryStore: store.NewMemoryStore(), failing: 1}, t)
+
+	b, contentType := constructFileUploadWithFields(sampleCSV, map[string]string{"processor": "test-counter"}, t)
+	resp, err := ts.Client().Post(ts.URL+"/upload", contentType, &b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("bad status: %s", resp.Status)
+	}
+
+	if files := listFiles(dir, t); len(files) != 0 {
+		t.Fatalf("expected the uploaded file to be removed, got: %v", files)
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	ts := setupServer(t)
+
+	resp, err := ts.Client().Get(ts.URL + "/debug/vars")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var vars map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&vars); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := vars["retries"].(float64); !ok {
+		t.Fatalf("expected the retries counter, got: %v", vars)
+	}
+}
+
 func TestOverAll(t *testing.T) {
 	ts := setupServer(t)
 
@@ -201,3 +362,858 @@ func TestStatusFinished(t *testing.T) {
 
 	checkStatus(id, task.TaskFinished, ts, t)
 }
+
+func TestUploadProcessor(t *testing.T) {
+	ts := setupServer(t)
+
+	id := uploadCSV(sampleCSV, map[string]string{"processor": "test-counter"}, ts, t)
+
+	time.Sleep(100 * time.Millisecond)
+
+	checkStatus(id, task.TaskFinished, ts, t)
+
+	if n := countedRecords(id, t); n != 4 {
+		t.Fatalf("incorrect number of processed records. expected: 4; got: %d", n)
+	}
+}
+
+func TestUploadUnknownProcessor(t *testing.T) {
+	ts := setupServer(t)
+
+	b, contentType := constructFileUploadWithFields(sampleCSV, map[string]string{"processor": "does-not-exist"}, t)
+
+	resp, err := ts.Client().Post(ts.URL+"/upload", contentType, &b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("bad status: %s", resp.Status)
+	}
+}
+
+func TestRestore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pipeline-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	ts := setupServerWithDir(dir, t)
+
+	id := uploadCSV(sampleCSV, map[string]string{"processor": "test-slow"}, ts, t)
+
+	time.Sleep(slowRecordDuration * 3 / 2)
+
+	requestAndCheckStatus(id, "/pause", task.TaskPaused, ts, t)
+
+	// Give the task some time to save the checkpoint.
+	time.Sleep(slowRecordDuration)
+
+	restarted := setupServerWithDir(dir, t)
+
+	checkStatus(id, task.TaskPaused, restarted, t)
+
+	requestAndCheckStatus(id, "/resume", task.TaskRunning, restarted, t)
+
+	time.Sleep(slowRecordDuration * 4)
+
+	checkStatus(id, task.TaskFinished, restarted, t)
+}
+
+func TestRestoreLabels(t *testing.T) {
+	dir := t.TempDir()
+	ts := setupServerWithDir(dir, t)
+
+	id := uploadCSV(sampleCSV, map[string]string{"processor": "test-slow", "labels": "team=x"}, ts, t)
+
+	requestAndCheckStatus(id, "/pause", task.TaskPaused, ts, t)
+	v1Request(http.MethodPatch, "/tasks/"+id, map[string]interface{}{"labels": map[string]string{"team": "y"}}, nil, ts, t)
+
+	// Labels edited while paused are kept without a store.
+	restarted := setupServerWithDir(dir, t)
+
+	var s snapshotV1
+	v1Request(http.MethodGet, "/tasks/"+id, nil, &s, restarted, t)
+	if s.Status != task.TaskPaused || s.Metadata.Labels["team"] != "y" {
+		t.Fatalf("incorrect restored task: %+v", s)
+	}
+}
+
+func TestRestoreBreakpoints(t *testing.T) {
+	dir := t.TempDir()
+	ts := setupServerWithDir(dir, t)
+
+	s := createTaskV1(map[string]interface{}{
+		"content": sampleCSV,
+		"config": map[string]interface{}{
+			"processor":   "test-counter",
+			"dialect":     map[string]interface{}{"header": true},
+			"breakpoints": []map[string]interface{}{{"row": 2}},
+		},
+	}, ts, t)
+
+	time.Sleep(100 * time.Millisecond)
+
+	checkStatus(s.ID, task.TaskPaused, ts, t)
+	v1Request(http.MethodPatch, "/tasks/"+s.ID, map[string]interface{}{"breakpoints": []map[string]interface{}{{"row": 3}}}, nil, ts, t)
+
+	// Breakpoints changed while paused are kept without a store.
+	restarted := setupServerWithDir(dir, t)
+
+	v1Request(http.MethodGet, "/tasks/"+s.ID, nil, &s, restarted, t)
+	if s.Status != task.TaskPaused || len(s.Breakpoints) != 1 || s.Breakpoints[0].Row != 3 {
+		t.Fatalf("incorrect restored task: %+v", s)
+	}
+
+	v1Request(http.MethodPost, "/tasks/"+s.ID+":resume", nil, nil, restarted, t)
+	time.Sleep(100 * time.Millisecond)
+	checkStatus(s.ID, task.TaskPaused, restarted, t)
+
+	v1Request(http.MethodPost, "/tasks/"+s.ID+":resume", nil, nil, restarted, t)
+	time.Sleep(100 * time.Millisecond)
+	checkStatus(s.ID, task.TaskFinished, restarted, t)
+}
+
+func TestRestoreFromStore(t *testing.T) {
+	dir := t.TempDir()
+	db := filepath.Join(dir, "pipeline.db")
+
+	s, err := store.OpenBoltStore(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := setupServerWithStore(dir, s, t)
+
+	id := uploadCSV(sampleCSV, map[string]string{"processor": "test-slow"}, ts, t)
+
+	time.Sleep(slowRecordDuration * 5)
+
+	checkStatus(id, task.TaskFinished, ts, t)
+	ts.Close()
+	s.Close()
+
+	// The store alone is enough to bring the tasks back.
+	checkpoints, _ := filepath.Glob(filepath.Join(dir, "*"+task.CheckpointExt))
+	for _, p := range checkpoints {
+		os.Remove(p)
+	}
+
+	s, err = store.OpenBoltStore(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+	restarted := setupServerWithStore(dir, s, t)
+
+	checkStatus(id, task.TaskFinished, restarted, t)
+}
+
+func TestStatusProgress(t *testing.T) {
+	ts := setupServer(t)
+
+	id := uploadCSV(sampleCSV, map[string]string{"processor": "test-slow"}, ts, t)
+
+	time.Sleep(slowRecordDuration * 3 / 2)
+
+	progress := getProgress(id, ts, t)
+	if progress.Processed != 1 || progress.Total != 4 || !progress.TotalExact {
+		t.Fatalf("incorrect progress while running: %+v", progress)
+	}
+	if progress.ETA <= 0 {
+		t.Fatalf("expected ETA while running: %+v", progress)
+	}
+
+	time.Sleep(slowRecordDuration * 4)
+
+	progress = getProgress(id, ts, t)
+	if progress.Processed != 4 || progress.Percent != 100 {
+		t.Fatalf("incorrect progress after finishing: %+v", progress)
+	}
+}
+
+func getProgress(id string, ts *httptest.Server, t *testing.T) task.Progress {
+	resp, err := ts.Client().PostForm(ts.URL+"/status", url.Values{"id": []string{id}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var res struct {
+		Data struct {
+			Progress task.Progress `json:"progress"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		t.Fatal(err)
+	}
+
+	return res.Data.Progress
+}
+
+func TestTimeout(t *testing.T) {
+	ts := setupServer(t)
+
+	id := uploadCSV(sampleCSV, map[string]string{"processor": "test-slow", "timeout": "300ms"}, ts, t)
+
+	time.Sleep(slowRecordDuration * 5 / 2)
+
+	checkStatus(id, task.TaskTimedOut, ts, t)
+
+	progress := getProgress(id, ts, t)
+	if progress.Processed != 1 {
+		t.Fatalf("incorrect number of processed records. expected: 1; got: %d", progress.Processed)
+	}
+}
+
+func TestUploadInvalidTimeout(t *testing.T) {
+	ts := setupServer(t)
+
+	for _, fields := range []map[string]string{
+		{"timeout": "soon"},
+		{"timeout": "-1s"},
+		{"deadline": "tomorrow"},
+	} {
+		b, contentType := constructFileUploadWithFields(sampleCSV, fields, t)
+
+		resp, err := ts.Client().Post(ts.URL+"/upload", contentType, &b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("bad status for %v: %s", fields, resp.Status)
+		}
+	}
+}
+
+func TestInvalidTransitionConflict(t *testing.T) {
+	ts := setupServer(t)
+
+	id := uploadCSV(sampleCSV, map[string]string{"processor": "test-counter"}, ts, t)
+
+	time.Sleep(100 * time.Millisecond)
+
+	checkStatus(id, task.TaskFinished, ts, t)
+
+	resp, err := ts.Client().PostForm(ts.URL+"/pause", url.Values{"id": []string{id}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusConflict {
+		t.Fatalf("bad status: %s", resp.Status)
+	}
+
+	if status := getStatus(resp.Body, t); status != task.TaskFinished {
+		t.Fatalf("incorrect status in conflict. expected: %s; got: %s", task.TaskFinished, status)
+	}
+}
+
+func TestStatusActions(t *testing.T) {
+	ts := setupServer(t)
+
+	id := uploadCSV(sampleCSV, map[string]string{"processor": "test-slow"}, ts, t)
+
+	resp, err := ts.Client().PostForm(ts.URL+"/status", url.Values{"id": []string{id}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var res struct {
+		Data struct {
+			Actions []task.Action `json:"actions"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []task.Action{task.ActionPause, task.ActionTerminate}
+	if !reflect.DeepEqual(res.Data.Actions, expected) {
+		t.Fatalf("incorrect actions. expected: %v; got: %v", expected, res.Data.Actions)
+	}
+}
+
+func TestTaskEvents(t *testing.T) {
+	ts := setupServer(t)
+
+	id := uploadCSV(sampleCSV, map[string]string{"processor": "test-slow", "actor": "alice"}, ts, t)
+
+	resp, err := ts.Client().PostForm(ts.URL+"/pause", url.Values{
+		"id":     []string{id},
+		"actor":  []string{"bob"},
+		"reason": []string{"maintenance"},
+		"wait":   []string{"1s"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	resp, err = ts.Client().Get(ts.URL + "/tasks/" + id + "/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("bad status: %s", resp.Status)
+	}
+
+	var res struct {
+		Data struct {
+			Events   []task.Event  `json:"events"`
+			Timeline task.Timeline `json:"timeline"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		t.Fatal(err)
+	}
+
+	var pause *task.Event
+	for i, e := range res.Data.Events {
+		if e.To == task.TaskPausing {
+			pause = &res.Data.Events[i]
+		}
+	}
+	if pause == nil || pause.Actor != "bob" || pause.Reason != "maintenance" {
+		t.Fatalf("pause event not recorded: %+v", res.Data.Events)
+	}
+	if last := res.Data.Events[len(res.Data.Events)-1]; last.To != task.TaskPaused {
+		t.Fatalf("expected the task to be paused, got events: %+v", res.Data.Events)
+	}
+	if res.Data.Timeline.StartedAt == nil || res.Data.Timeline.FinishedAt != nil {
+		t.Fatalf("incorrect timeline: %+v", res.Data.Timeline)
+	}
+}
+
+func TestTaskEventsNotFound(t *testing.T) {
+	ts := setupServer(t)
+
+	for _, path := range []string{"/tasks/does-not-exist/events", "/tasks/", "/tasks/x/y/z"} {
+		resp, err := ts.Client().Get(ts.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusNotFound {
+			t.Fatalf("bad status for %s: %s", path, resp.Status)
+		}
+	}
+}
+
+func getOutput(id string, ts *httptest.Server, t *testing.T) (*http.Response, string) {
+	resp, err := ts.Client().Get(ts.URL + "/tasks/" + id + "/output")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp, string(b)
+}
+
+func TestTaskOutput(t *testing.T) {
+	ts := setupServer(t)
+
+	id := uploadCSV(sampleCSV, map[string]string{"processor": "test-slow"}, ts, t)
+
+	time.Sleep(slowRecordDuration * 3 / 2)
+
+	requestAndCheckStatus(id, "/pause", task.TaskPaused, ts, t)
+
+	resp, partial := getOutput(id, ts, t)
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("X-Output-Partial") != "true" {
+		t.Fatalf("bad response for paused task: %s, partial: %q", resp.Status, resp.Header.Get("X-Output-Partial"))
+	}
+	if partial != "id,name\n1,x\n" {
+		t.Fatalf("incorrect partial output: %q", partial)
+	}
+
+	requestAndCheckStatus(id, "/resume", task.TaskRunning, ts, t)
+
+	resp, _ = getOutput(id, ts, t)
+	if resp.StatusCode != http.StatusConflict {
+		t.Fatalf("bad status for running task: %s", resp.Status)
+	}
+
+	time.Sleep(slowRecordDuration * 3)
+
+	checkStatus(id, task.TaskFinished, ts, t)
+
+	resp, output := getOutput(id, ts, t)
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("X-Output-Partial") != "false" {
+		t.Fatalf("bad response for finished task: %s, partial: %q", resp.Status, resp.Header.Get("X-Output-Partial"))
+	}
+	if resp.Header.Get("Content-Type") != "text/csv" {
+		t.Fatalf("incorrect content type: %s", resp.Header.Get("Content-Type"))
+	}
+	if output != sampleCSV+"\n" {
+		t.Fatalf("incorrect output: %q", output)
+	}
+}
+
+func TestUploadUnknownOutput(t *testing.T) {
+	ts := setupServer(t)
+
+	b, contentType := constructFileUploadWithFields(sampleCSV, map[string]string{"output": "xml"}, t)
+
+	resp, err := ts.Client().Post(ts.URL+"/upload", contentType, &b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("bad status: %s", resp.Status)
+	}
+}
+
+func TestUploadDialect(t *testing.T) {
+	ts := setupServer(t)
+
+	id := uploadCSV("id\tname\n1\tx\n2\t\"y,z\"\n", map[string]string{
+		"processor": "test-slow",
+		"delimiter": `\t`,
+		"header":    "true",
+	}, ts, t)
+
+	time.Sleep(slowRecordDuration * 3)
+
+	checkStatus(id, task.TaskFinished, ts, t)
+
+	if progress := getProgress(id, ts, t); progress.Processed != 2 {
+		t.Fatalf("incorrect number of processed records. expected: 2; got: %d", progress.Processed)
+	}
+
+	_, output := getOutput(id, ts, t)
+	if expected := "id,name\n1,x\n2,\"y,z\"\n"; output != expected {
+		t.Fatalf("incorrect output. expected: %q; got: %q", expected, output)
+	}
+}
+
+func TestUploadInvalidDialect(t *testing.T) {
+	ts := setupServer(t)
+
+	for _, fields := range []map[string]string{
+		{"delimiter": ";;"},
+		{"delimiter": `"`},
+		{"comment": "\n"},
+		{"header": "maybe"},
+		{"fieldsPerRecord": "some"},
+	} {
+		b, contentType := constructFileUploadWithFields(sampleCSV, fields, t)
+
+		resp, err := ts.Client().Post(ts.URL+"/upload", contentType, &b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("bad status for %v: %s", fields, resp.Status)
+		}
+	}
+}
+
+func TestUploadMalformed(t *testing.T) {
+	ts := setupServer(t)
+
+	id := uploadCSV("1,a\n2,b\"\n3,c\n", map[string]string{"processor": "test-counter", "malformed": "skip"}, ts, t)
+
+	time.Sleep(100 * time.Millisecond)
+
+	checkStatus(id, task.TaskFinished, ts, t)
+
+	if progress := getProgress(id, ts, t); progress.Processed != 2 || progress.Skipped != 1 {
+		t.Fatalf("incorrect progress: %+v", progress)
+	}
+
+	for _, fields := range []map[string]string{
+		{"malformed": "ignore"},
+		{"errorBudget": "lots"},
+		{"errorBudget": "101"},
+	} {
+		b, contentType := constructFileUploadWithFields(sampleCSV, fields, t)
+
+		resp, err := ts.Client().Post(ts.URL+"/upload", contentType, &b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("bad status for %v: %s", fields, resp.Status)
+		}
+	}
+}
+
+func TestStatusError(t *testing.T) {
+	ts := setupServer(t)
+
+	var res struct {
+		Data struct {
+			Error     *task.Error  `json:"error"`
+			RowErrors []task.Error `json:"rowErrors"`
+		} `json:"data"`
+	}
+	getErrors := func(id string) {
+		resp, err := ts.Client().PostForm(ts.URL+"/status", url.Values{"id": []string{id}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+
+		if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	failed := uploadCSV("1,a\n2,b\"\n", map[string]string{"processor": "test-counter"}, ts, t)
+	skipped := uploadCSV("1,a\n2,b\"\n", map[string]string{"processor": "test-counter", "malformed": "skip"}, ts, t)
+
+	time.Sleep(100 * time.Millisecond)
+
+	checkStatus(failed, task.TaskGotError, ts, t)
+	getErrors(failed)
+	if e := res.Data.Error; e == nil || e.Row != 2 || e.Line != `2,b"` {
+		t.Fatalf("incorrect error: %+v", e)
+	}
+
+	checkStatus(skipped, task.TaskFinished, ts, t)
+	getErrors(skipped)
+	if res.Data.Error != nil || len(res.Data.RowErrors) != 1 || res.Data.RowErrors[0].Row != 2 {
+		t.Fatalf("incorrect row errors: %+v", res.Data)
+	}
+}
+
+func TestQuarantineReplayStoreError(t *testing.T) {
+	dir := t.TempDir()
+	s := &failingStore{MemoryStore: store.NewMemoryStore()}
+	ts := setupServerWithStore(dir, s, t)
+
+	id := uploadCSV("1,a\n2,b\"\n3,c\n", map[string]string{"processor": "test-counter", "malformed": "quarantine"}, ts, t)
+
+	time.Sleep(100 * time.Millisecond)
+
+	checkStatus(id, task.TaskFinished, ts, t)
+	files := listFiles(dir, t)
+
+	atomic.StoreInt32(&s.failing, 1)
+	resp, err := ts.Client().PostForm(ts.URL+"/tasks/"+id+"/replay", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("bad status: %s", resp.Status)
+	}
+
+	if left := listFiles(dir, t); !reflect.DeepEqual(left, files) {
+		t.Fatalf("expected the files of the replay to be removed. before: %v; after: %v", files, left)
+	}
+}
+
+func TestQuarantineReplayBreakpoints(t *testing.T) {
+	ts := setupServer(t)
+
+	// Neither breakpoint matches a record of the file.
+	parent := createTaskV1(map[string]interface{}{
+		"content": "1,a\n2,b\"\n3,c\n",
+		"config": map[string]interface{}{
+			"processor":   "test-counter",
+			"malformed":   "quarantine",
+			"breakpoints": []map[string]interface{}{{"row": 5}, {"field": "1", "value": "9"}},
+		},
+	}, ts, t)
+
+	time.Sleep(100 * time.Millisecond)
+
+	checkStatus(parent.ID, task.TaskFinished, ts, t)
+
+	var replay struct {
+		ID string `json:"id"`
+	}
+	if resp := v1Request(http.MethodPost, "/tasks/"+parent.ID+"/replay", nil, &replay, ts, t); resp.StatusCode != http.StatusOK {
+		t.Fatalf("bad status: %s", resp.Status)
+	}
+
+	var s snapshotV1
+	v1Request(http.MethodGet, "/tasks/"+replay.ID, nil, &s, ts, t)
+	if bps := s.Config.Breakpoints; len(bps) != 1 || bps[0].Row != 0 || bps[0].Field != "1" {
+		t.Fatalf("expected only the field breakpoint to be kept, got: %+v", bps)
+	}
+}
+
+func TestQuarantineReplay(t *testing.T) {
+	ts := setupServer(t)
+
+	id := uploadCSV("1,a\n2,b\"\n3,c\n", map[string]string{"processor": "test-counter", "malformed": "quarantine"}, ts, t)
+	clean := uploadCSV(sampleCSV, map[string]string{"processor": "test-counter", "malformed": "quarantine"}, ts, t)
+
+	time.Sleep(100 * time.Millisecond)
+
+	checkStatus(id, task.TaskFinished, ts, t)
+
+	resp, err := ts.Client().Get(ts.URL + "/tasks/" + id + "/quarantine")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK || !bytes.Contains(b, []byte(`"2,b"""`)) {
+		t.Fatalf("bad quarantine: %s, %q", resp.Status, b)
+	}
+
+	resp, err = ts.Client().Get(ts.URL + "/tasks/" + id + "/replay")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Fatalf("bad status for GET replay: %s", resp.Status)
+	}
+
+	resp, err = ts.Client().PostForm(ts.URL+"/tasks/"+clean+"/replay", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusConflict {
+		t.Fatalf("bad status for replay without quarantined records: %s", resp.Status)
+	}
+
+	resp, err = ts.Client().PostForm(ts.URL+"/tasks/"+id+"/replay", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("bad status for replay: %s", resp.Status)
+	}
+	replay := getID(resp.Body, t)
+
+	time.Sleep(100 * time.Millisecond)
+
+	checkStatus(replay, task.TaskFinished, ts, t)
+	if progress := getProgress(replay, ts, t); progress.Total != 1 || progress.Quarantined != 1 {
+		t.Fatalf("incorrect progress of replay: %+v", progress)
+	}
+}
+
+func TestUploadInvalidRetry(t *testing.T) {
+	ts := setupServer(t)
+
+	for _, fields := range []map[string]string{
+		{"retryAttempts": "many"},
+		{"retryAttempts": "-1"},
+		{"retryBackoff": "soon"},
+		{"retryMaxBackoff": "-1s"},
+	} {
+		b, contentType := constructFileUploadWithFields(sampleCSV, fields, t)
+
+		resp, err := ts.Client().Post(ts.URL+"/upload", contentType, &b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("bad status for %v: %s", fields, resp.Status)
+		}
+	}
+}
+
+func TestConcurrentRequests(t *testing.T) {
+	ts := setupServer(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		b, contentType := constructFileUploadWithFields(sampleCSV, map[string]string{"processor": "test-counter"}, t)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			resp, err := ts.Client().Post(ts.URL+"/upload", contentType, &b)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer resp.Body.Close()
+
+			var res struct {
+				Data struct {
+					ID string `json:"id"`
+				} `json:"data"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+				t.Error(err)
+				return
+			}
+			id := res.Data.ID
+
+			for _, path := range []string{"/status", "/pause", "/resume", "/status"} {
+				resp, err := ts.Client().PostForm(ts.URL+path, url.Values{"id": []string{id}})
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				resp.Body.Close()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestUploadWithoutStart(t *testing.T) {
+	ts := setupServer(t)
+
+	id := uploadCSV(sampleCSV, map[string]string{"processor": "test-counter", "start": "false"}, ts, t)
+
+	time.Sleep(100 * time.Millisecond)
+
+	checkStatus(id, task.TaskNotStarted, ts, t)
+	if _, ok := counters.Load(id); ok {
+		t.Fatal("expected the task not to be processed")
+	}
+
+	resp, err := ts.Client().PostForm(ts.URL+"/start", url.Values{"id": []string{id}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("bad status when starting: %s", resp.Status)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	checkStatus(id, task.TaskFinished, ts, t)
+
+	resp, err = ts.Client().PostForm(ts.URL+"/start", url.Values{"id": []string{id}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusConflict {
+		t.Fatalf("bad status when starting again: %s", resp.Status)
+	}
+}
+
+func TestUploadStartAt(t *testing.T) {
+	ts := setupServer(t)
+
+	startAt := time.Now().Add(300 * time.Millisecond).Format(time.RFC3339Nano)
+	id := uploadCSV(sampleCSV, map[string]string{"processor": "test-counter", "startAt": startAt}, ts, t)
+
+	time.Sleep(100 * time.Millisecond)
+
+	checkStatus(id, task.TaskNotStarted, ts, t)
+
+	time.Sleep(400 * time.Millisecond)
+
+	checkStatus(id, task.TaskFinished, ts, t)
+}
+
+func TestUploadInvalidStart(t *testing.T) {
+	ts := setupServer(t)
+
+	for _, fields := range []map[string]string{{"start": "maybe"}, {"startAt": "tomorrow"}} {
+		b, contentType := constructFileUploadWithFields(sampleCSV, fields, t)
+
+		resp, err := ts.Client().Post(ts.URL+"/upload", contentType, &b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("bad status for %v: %s", fields, resp.Status)
+		}
+	}
+}
+
+func TestUploadRange(t *testing.T) {
+	ts := setupServer(t)
+
+	id := uploadCSV(sampleCSV, map[string]string{"processor": "test-counter", "header": "true", "startRow": "2", "endRow": "2"}, ts, t)
+
+	time.Sleep(100 * time.Millisecond)
+
+	checkStatus(id, task.TaskFinished, ts, t)
+	if n := countedRecords(id, t); n != 1 {
+		t.Fatalf("expected 1 record processed, got: %d", n)
+	}
+	if progress := getProgress(id, ts, t); progress.Total != 1 || progress.Percent != 100 {
+		t.Fatalf("incorrect progress: %+v", progress)
+	}
+
+	for _, fields := range []map[string]string{{"startRow": "x"}, {"startRow": "3", "endRow": "2"}, {"startRow": "2", "endOffset": "10"}} {
+		b, contentType := constructFileUploadWithFields(sampleCSV, fields, t)
+
+		resp, err := ts.Client().Post(ts.URL+"/upload", contentType, &b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("bad status for %v: %s", fields, resp.Status)
+		}
+	}
+}
+
+func TestUploadBreakpoint(t *testing.T) {
+	ts := setupServer(t)
+
+	id := uploadCSV(sampleCSV, map[string]string{"processor": "test-counter", "header": "true", "breakpoint": "name=z"}, ts, t)
+
+	time.Sleep(100 * time.Millisecond)
+
+	checkStatus(id, task.TaskPaused, ts, t)
+	if n := countedRecords(id, t); n != 2 {
+		t.Fatalf("expected 2 records processed, got: %d", n)
+	}
+	resp, err := ts.Client().PostForm(ts.URL+"/step", url.Values{"id": []string{id}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("bad status /step: %s", resp.Status)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	checkStatus(id, task.TaskPaused, ts, t)
+	if n := countedRecords(id, t); n != 3 {
+		t.Fatalf("expected 3 records processed, got: %d", n)
+	}
+
+	for _, bp := range []string{"x", "0"} {
+		b, contentType := constructFileUploadWithFields(sampleCSV, map[string]string{"breakpoint": bp}, t)
+
+		resp, err := ts.Client().Post(ts.URL+"/upload", contentType, &b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("bad status for breakpoint %q: %s", bp, resp.Status)
+		}
+	}
+}
@@ -0,0 +1,375 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prmsrswt/pipeline/pkg/store"
+	"github.com/prmsrswt/pipeline/pkg/task"
+)
+
+// v1Prefix is the path under which the versioned resource API is served.
+const v1Prefix = "/api/v1"
+
+// maxBodySize is the largest JSON body accepted, leaving room for escaping
+// the content of 50 MB files.
+const maxBodySize = 64 << 20
+
+// errUnsupportedBody is returned when the body of a request isn't JSON.
+var errUnsupportedBody = errors.New("request body must be JSON")
+
+// createRequest is the body of a request creating a task.
+type createRequest struct {
+	// Filename is the name of the uploaded file.
+	Filename string `json:"filename"`
+	// Content is the CSV data to process.
+	Content string `json:"content"`
+	// ContentType is the media type of the content, text/csv if empty.
+	ContentType string   `json:"contentType"`
+	Config      configV1 `json:"config"`
+	// Labels are key/value pairs to find the task by.
+	Labels map[string]string `json:"labels"`
+	// Start submits the task right away, it defaults to true. Tasks which
+	// aren't started wait in not-started status, see also Config.StartAt.
+	Start  *bool  `json:"start"`
+	Actor  string `json:"actor"`
+	Reason string `json:"reason"`
+}
+
+// updateRequest is the body of a request editing a task.
+type updateRequest struct {
+	// Labels are set to the given values, or removed if null.
+	Labels map[string]*string `json:"labels"`
+	// Breakpoints replace the breakpoints of the task, unless null.
+	Breakpoints *[]task.Breakpoint `json:"breakpoints"`
+}
+
+// controlRequest is the optional body of a request controlling a task.
+type controlRequest struct {
+	Actor  string `json:"actor"`
+	Reason string `json:"reason"`
+	// Wait is how long to wait for the worker to apply the change, e.g. "2s".
+	Wait string `json:"wait"`
+}
+
+// control is a control action of a task, requested at /api/v1/tasks/{id}:{action}.
+type control struct {
+	op      func(*task.Task, task.Cause) error
+	message string
+}
+
+// newControls returns the control actions of the tasks, started using the scheduler.
+func newControls(s *task.Scheduler) map[string]control {
+	return map[string]control{
+		"start":     {s.Submit, "task start requested"},
+		"pause":     {(*task.Task).Pause, "task pause requested"},
+		"resume":    {(*task.Task).Resume, "task resumed"},
+		"step":      {(*task.Task).Step, "task step requested"},
+		"terminate": {(*task.Task).Terminate, "task termination requested"},
+	}
+}
+
+// decodeBody decodes the JSON body of the request into v, an empty body
+// leaving v untouched.
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) error {
+	if ct := r.Header.Get("Content-Type"); ct != "" {
+		if mt, _, err := mime.ParseMediaType(ct); err != nil || mt != "application/json" {
+			return errUnsupportedBody
+		}
+	}
+
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+// respondBodyError tells the client why its request body couldn't be decoded.
+func respondBodyError(w http.ResponseWriter, err error) {
+	if err == errUnsupportedBody {
+		respondError(w, err.Error(), http.StatusUnsupportedMediaType)
+		return
+	}
+	respondError(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+}
+
+// handleTasksV1 serves the collection of tasks at /api/v1/tasks.
+func (a *API) handleTasksV1(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		a.listTasks(w, r)
+	case http.MethodPost:
+		a.createTaskV1(w, r)
+	default:
+		methodNotAllowed(w, http.MethodGet, http.MethodPost)
+	}
+}
+
+// handleTaskV1 serves a task at /api/v1/tasks/{id}, its control actions at
+// /api/v1/tasks/{id}:{action} and its resources at /api/v1/tasks/{id}/{resource}.
+func (a *API) handleTaskV1(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, v1Prefix+"/tasks/")
+
+	var resource, action string
+	if i := strings.IndexByte(id, '/'); i >= 0 {
+		id, resource = id[:i], id[i+1:]
+		if !taskResources[resource] {
+			respondError(w, "not found", http.StatusNotFound)
+			return
+		}
+	} else if i := strings.LastIndexByte(id, ':'); i >= 0 {
+		id, action = id[:i], id[i+1:]
+		if _, ok := a.controls[action]; !ok {
+			respondError(w, "unknown action", http.StatusNotFound)
+			return
+		}
+	}
+
+	t, err := a.store.Get(id)
+	if err != nil {
+		respondError(w, "task not found", http.StatusNotFound)
+		return
+	}
+
+	switch {
+	case resource != "":
+		a.serveResource(w, r, t, resource)
+	case action != "":
+		a.controlV1(w, r, t, a.controls[action])
+	case r.Method == http.MethodGet:
+		respondSuccess(w, newSnapshotV1(t.Snapshot()))
+	case r.Method == http.MethodPatch:
+		a.updateTask(w, r, t)
+	case r.Method == http.MethodDelete:
+		a.deleteTask(w, r, t)
+	default:
+		methodNotAllowed(w, http.MethodGet, http.MethodPatch, http.MethodDelete)
+	}
+}
+
+// listTasks responds with a page of the tasks matching the query parameters,
+// oldest first unless sorted otherwise.
+func (a *API) listTasks(w http.ResponseWriter, r *http.Request) {
+	q, err := parseQuery(r)
+	if err != nil {
+		respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	page, err := store.Search(a.store, q)
+	if err != nil {
+		if errors.Is(err, store.ErrInvalidQuery) {
+			respondError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		respondError(w, "error listing tasks", http.StatusInternalServerError)
+		log.Println("[error] listing tasks: ", err)
+		return
+	}
+	respondSuccess(w, newPageV1(page))
+}
+
+// parseQuery returns the search query from the URL query parameters. The
+// status and label parameters may be repeated or hold comma separated values.
+func parseQuery(r *http.Request) (store.Query, error) {
+	values := r.URL.Query()
+	q := store.Query{
+		Filename: values.Get("filename"),
+		Parent:   values.Get("parent"),
+		Sort:     strings.TrimPrefix(values.Get("sort"), "-"),
+		Desc:     strings.HasPrefix(values.Get("sort"), "-"),
+		Cursor:   values.Get("cursor"),
+	}
+
+	for _, v := range values["status"] {
+		for _, s := range strings.Split(v, ",") {
+			q.Statuses = append(q.Statuses, task.Status(s))
+		}
+	}
+
+	for _, v := range values["label"] {
+		sel, err := task.ParseSelector(v)
+		if err != nil {
+			return q, err
+		}
+		q.Selector = append(q.Selector, sel...)
+	}
+
+	var err error
+	for name, field := range map[string]*time.Time{
+		"createdAfter":  &q.CreatedAfter,
+		"createdBefore": &q.CreatedBefore,
+	} {
+		if v := values.Get(name); v != "" {
+			if *field, err = time.Parse(time.RFC3339, v); err != nil {
+				return q, fmt.Errorf("invalid %s", name)
+			}
+		}
+	}
+
+	if v := values.Get("limit"); v != "" {
+		if q.Limit, err = strconv.Atoi(v); err != nil || q.Limit <= 0 {
+			return q, errors.New("invalid limit")
+		}
+	}
+
+	return q, nil
+}
+
+// createTaskV1 creates a task processing the content of the request and
+// responds with its snapshot.
+func (a *API) createTaskV1(w http.ResponseWriter, r *http.Request) {
+	var req createRequest
+	if err := decodeBody(w, r, &req); err != nil {
+		respondBodyError(w, err)
+		return
+	}
+	if req.Content == "" {
+		respondError(w, "content is required", http.StatusBadRequest)
+		return
+	}
+	if req.Filename == "" {
+		req.Filename = "upload.csv"
+	}
+
+	if req.ContentType == "" {
+		req.ContentType = "text/csv"
+	}
+
+	cfg, err := req.Config.config()
+	if err != nil {
+		respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	meta := task.Metadata{Filename: req.Filename, ContentType: req.ContentType, Labels: req.Labels}
+	start := req.Start == nil || *req.Start
+	t := a.createTask(w, meta, cfg, strings.NewReader(req.Content), clientCause(r, req.Actor, req.Reason), start)
+	if t == nil {
+		return
+	}
+
+	w.Header().Set("Location", v1Prefix+"/tasks/"+t.ID)
+	respond(w, response{Status: "success", Data: newSnapshotV1(t.Snapshot())}, http.StatusCreated)
+
+	log.Println("[success] task created: ", t.ID)
+}
+
+// controlV1 applies the control action to the task.
+func (a *API) controlV1(w http.ResponseWriter, r *http.Request, t *task.Task, c control) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, http.MethodPost)
+		return
+	}
+
+	var req controlRequest
+	if err := decodeBody(w, r, &req); err != nil {
+		respondBodyError(w, err)
+		return
+	}
+
+	wait, err := parseWait(req.Wait)
+	if err != nil {
+		respondError(w, "invalid wait duration", http.StatusBadRequest)
+		return
+	}
+
+	a.control(w, r, t, c.op, clientCause(r, req.Actor, req.Reason), wait, c.message)
+}
+
+// updateTask edits the labels and breakpoints of the task and responds with its snapshot.
+func (a *API) updateTask(w http.ResponseWriter, r *http.Request, t *task.Task) {
+	var req updateRequest
+	if err := decodeBody(w, r, &req); err != nil {
+		respondBodyError(w, err)
+		return
+	}
+
+	set := make(map[string]string)
+	var remove []string
+	for k, v := range req.Labels {
+		if v == nil {
+			remove = append(remove, k)
+			continue
+		}
+		set[k] = *v
+	}
+
+	// Everything is validated first, so that the task is left untouched
+	// unless all of it can be applied.
+	if err := task.ValidateLabels(set); err != nil {
+		respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.Breakpoints != nil {
+		if err := task.ValidateBreakpoints(*req.Breakpoints); err != nil {
+			respondError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	if err := t.UpdateLabels(set, remove); err != nil {
+		respondError(w, err.Error(), http.StatusInternalServerError)
+		log.Println("[error] updating labels: ", err)
+		return
+	}
+	if req.Breakpoints != nil {
+		if err := t.SetBreakpoints(*req.Breakpoints); err != nil {
+			respondError(w, err.Error(), http.StatusInternalServerError)
+			log.Println("[error] setting breakpoints: ", err)
+			return
+		}
+	}
+	respondSuccess(w, newSnapshotV1(t.Snapshot()))
+
+	log.Println("[success] task updated: ", t.ID)
+}
+
+// deleteTask removes a task which isn't running anymore, along with its files.
+func (a *API) deleteTask(w http.ResponseWriter, r *http.Request, t *task.Task) {
+	status := t.Status()
+	if !deletable(status) {
+		respondConflict(w, "only tasks which are stopped or never started can be deleted", status)
+		return
+	}
+
+	// The worker may still be writing the last checkpoint of the task.
+	if status != task.TaskNotStarted {
+		select {
+		case <-t.Done():
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if err := a.store.Delete(t.ID); err != nil {
+		respondError(w, "error deleting task", http.StatusInternalServerError)
+		log.Println("[error] deleting task: ", err)
+		return
+	}
+
+	removeFiles(t)
+	respondSuccess(w, map[string]string{"message": "task deleted", "id": t.ID})
+
+	log.Println("[success] task deleted: ", t.ID)
+}
+
+// deletable reports whether a task in this status has no worker using its files.
+func deletable(status task.Status) bool {
+	switch status {
+	case task.TaskNotStarted, task.TaskFinished, task.TaskGotError, task.TaskTerminated, task.TaskTimedOut:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,514 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prmsrswt/pipeline/pkg/task"
+)
+
+// v1Request sends a request to the v1 API with the JSON encoded body, if not
+// nil, and decodes the data of the response into data, if not nil.
+func v1Request(method, path string, body, data interface{}, ts *httptest.Server, t *testing.T) *http.Response {
+	t.Helper()
+
+	var b bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&b).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	req, err := http.NewRequest(method, ts.URL+v1Prefix+path, &b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := ts.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if data != nil {
+		res := response{Data: data}
+		if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return resp
+}
+
+func createTaskV1(body map[string]interface{}, ts *httptest.Server, t *testing.T) snapshotV1 {
+	t.Helper()
+
+	var s snapshotV1
+	resp := v1Request(http.MethodPost, "/tasks", body, &s, ts, t)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("bad status: %s", resp.Status)
+	}
+	if loc := resp.Header.Get("Location"); loc != v1Prefix+"/tasks/"+s.ID {
+		t.Fatalf("incorrect location: %s", loc)
+	}
+	return s
+}
+
+func TestV1Lifecycle(t *testing.T) {
+	ts := setupServer(t)
+
+	created := createTaskV1(map[string]interface{}{
+		"filename": "test.csv",
+		"content":  sampleCSV,
+		"config":   map[string]interface{}{"processor": "test-slow"},
+		"actor":    "alice",
+	}, ts, t)
+	if created.Config.Processor != "test-slow" {
+		t.Fatalf("incorrect config: %+v", created.Config)
+	}
+
+	var list struct {
+		Tasks []snapshotV1 `json:"tasks"`
+	}
+	v1Request(http.MethodGet, "/tasks", nil, &list, ts, t)
+	if len(list.Tasks) != 1 || list.Tasks[0].ID != created.ID {
+		t.Fatalf("incorrect list: %+v", list.Tasks)
+	}
+
+	// Tasks can't be deleted while running.
+	if resp := v1Request(http.MethodDelete, "/tasks/"+created.ID, nil, nil, ts, t); resp.StatusCode != http.StatusConflict {
+		t.Fatalf("bad delete status: %s", resp.Status)
+	}
+
+	var control struct {
+		Status task.Status `json:"status"`
+	}
+	body := map[string]string{"actor": "bob", "reason": "maintenance", "wait": "2s"}
+	v1Request(http.MethodPost, "/tasks/"+created.ID+":pause", body, &control, ts, t)
+	if control.Status != task.TaskPaused {
+		t.Fatalf("expected paused, got: %s", control.Status)
+	}
+	v1Request(http.MethodPost, "/tasks/"+created.ID+":terminate", map[string]string{"wait": "2s"}, &control, ts, t)
+	if control.Status != task.TaskTerminated {
+		t.Fatalf("expected terminated, got: %s", control.Status)
+	}
+
+	var s snapshotV1
+	v1Request(http.MethodGet, "/tasks/"+created.ID, nil, &s, ts, t)
+	if s.Status != task.TaskTerminated || s.Timeline.FinishedAt == nil {
+		t.Fatalf("incorrect snapshot: %+v", s)
+	}
+
+	var events struct {
+		Events []task.Event `json:"events"`
+	}
+	v1Request(http.MethodGet, "/tasks/"+created.ID+"/events", nil, &events, ts, t)
+	if len(events.Events) < 2 || events.Events[1].Actor != "alice" {
+		t.Fatalf("incorrect events: %+v", events.Events)
+	}
+
+	if resp := v1Request(http.MethodDelete, "/tasks/"+created.ID, nil, nil, ts, t); resp.StatusCode != http.StatusOK {
+		t.Fatalf("bad delete status: %s", resp.Status)
+	}
+	if resp := v1Request(http.MethodGet, "/tasks/"+created.ID, nil, nil, ts, t); resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("bad status after delete: %s", resp.Status)
+	}
+}
+
+func TestV1Config(t *testing.T) {
+	ts := setupServer(t)
+
+	s := createTaskV1(map[string]interface{}{
+		"content": "id;name\n1;x\n",
+		"config": map[string]interface{}{
+			"processor": "test-counter",
+			"timeout":   "90s",
+			"dialect":   map[string]interface{}{"delimiter": ";", "comment": "#", "header": true},
+			"retry":     map[string]interface{}{"maxAttempts": 3, "backoff": "100ms", "maxBackoff": "2s"},
+		},
+		"start": false,
+	}, ts, t)
+
+	expected := configV1{
+		Processor: "test-counter",
+		Timeout:   "1m30s",
+		Output:    "csv",
+		Dialect:   dialectV1{Delimiter: ";", Comment: "#", Header: true},
+		Malformed: task.MalformedFail,
+		Retry:     retryV1{MaxAttempts: 3, Backoff: "100ms", MaxBackoff: "2s"},
+	}
+	if !reflect.DeepEqual(s.Config, expected) {
+		t.Fatalf("incorrect config. expected: %+v; got: %+v", expected, s.Config)
+	}
+
+	for _, cfg := range []map[string]interface{}{
+		{"dialect": map[string]interface{}{"delimiter": 59}},
+		{"dialect": map[string]interface{}{"delimiter": ";;"}},
+		{"timeout": 90000000000},
+		{"timeout": "soon"},
+		{"retry": map[string]interface{}{"backoff": "-"}},
+		{"checkpointInterval": "1s"},
+	} {
+		body := map[string]interface{}{"content": sampleCSV, "config": cfg}
+		if resp := v1Request(http.MethodPost, "/tasks", body, nil, ts, t); resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("bad status for %v: %s", cfg, resp.Status)
+		}
+	}
+}
+
+func TestV1DeleteRemovesFiles(t *testing.T) {
+	dir := t.TempDir()
+	ts := setupServerWithDir(dir, t)
+
+	s := createTaskV1(map[string]interface{}{
+		"content": sampleCSV,
+		"config":  map[string]interface{}{"processor": "test-counter"},
+	}, ts, t)
+
+	time.Sleep(100 * time.Millisecond)
+
+	if resp := v1Request(http.MethodDelete, "/tasks/"+s.ID, nil, nil, ts, t); resp.StatusCode != http.StatusOK {
+		t.Fatalf("bad delete status: %s", resp.Status)
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 0 {
+		t.Fatalf("expected the files of the task to be removed, got: %v", files)
+	}
+}
+
+func TestV1Errors(t *testing.T) {
+	ts := setupServer(t)
+
+	s := createTaskV1(map[string]interface{}{"content": sampleCSV, "config": map[string]interface{}{"processor": "test-slow"}}, ts, t)
+
+	tests := []struct {
+		method, path string
+		body         interface{}
+		code         int
+	}{
+		{http.MethodGet, "/tasks/does-not-exist", nil, http.StatusNotFound},
+		{http.MethodPost, "/tasks/does-not-exist:pause", nil, http.StatusNotFound},
+		{http.MethodPost, "/tasks/" + s.ID + ":explode", nil, http.StatusNotFound},
+		{http.MethodGet, "/tasks/" + s.ID + "/unknown", nil, http.StatusNotFound},
+		{http.MethodGet, "/tasks/" + s.ID + ":pause", nil, http.StatusMethodNotAllowed},
+		{http.MethodPut, "/tasks/" + s.ID, nil, http.StatusMethodNotAllowed},
+		{http.MethodDelete, "/tasks", nil, http.StatusMethodNotAllowed},
+		{http.MethodPost, "/tasks/" + s.ID + ":resume", nil, http.StatusConflict},
+		{http.MethodPost, "/tasks/" + s.ID + ":pause", map[string]string{"wait": "soon"}, http.StatusBadRequest},
+		{http.MethodPost, "/tasks/" + s.ID + ":pause", map[string]string{"unknown": "field"}, http.StatusBadRequest},
+		{http.MethodPost, "/tasks", map[string]interface{}{}, http.StatusBadRequest},
+		{http.MethodPost, "/tasks", map[string]interface{}{"content": sampleCSV, "config": map[string]string{"output": "xml"}}, http.StatusBadRequest},
+	}
+
+	for _, tc := range tests {
+		resp := v1Request(tc.method, tc.path, tc.body, nil, ts, t)
+		if resp.StatusCode != tc.code {
+			t.Errorf("%s %s: expected %d, got: %s", tc.method, tc.path, tc.code, resp.Status)
+		}
+		if resp.StatusCode == http.StatusMethodNotAllowed && resp.Header.Get("Allow") == "" {
+			t.Errorf("%s %s: expected allowed methods", tc.method, tc.path)
+		}
+	}
+
+	resp, err := ts.Client().Post(ts.URL+v1Prefix+"/tasks", "text/csv", strings.NewReader(sampleCSV))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnsupportedMediaType {
+		t.Fatalf("bad status for CSV body: %s", resp.Status)
+	}
+}
+
+func TestV1ListTasks(t *testing.T) {
+	ts := setupServer(t)
+
+	a := createTaskV1(map[string]interface{}{
+		"filename": "jan.csv",
+		"content":  sampleCSV,
+		"config":   map[string]interface{}{"processor": "test-counter"},
+		"labels":   map[string]string{"team": "billing"},
+	}, ts, t)
+	b := uploadCSV(sampleCSV, map[string]string{"processor": "test-slow", "labels": "team=billing,env=prod"}, ts, t)
+	createTaskV1(map[string]interface{}{"filename": "feb.csv", "content": sampleCSV, "config": map[string]interface{}{"processor": "test-slow"}}, ts, t)
+
+	time.Sleep(100 * time.Millisecond)
+
+	var page struct {
+		Tasks      []snapshotV1 `json:"tasks"`
+		NextCursor string       `json:"nextCursor"`
+	}
+	v1Request(http.MethodGet, "/tasks?label=team=billing&status=running,paused", nil, &page, ts, t)
+	if len(page.Tasks) != 1 || page.Tasks[0].ID != b || page.Tasks[0].Metadata.Labels["env"] != "prod" {
+		t.Fatalf("incorrect tasks: %+v", page.Tasks)
+	}
+
+	v1Request(http.MethodGet, "/tasks?filename=jan.*", nil, &page, ts, t)
+	if len(page.Tasks) != 1 || page.Tasks[0].ID != a.ID || page.Tasks[0].Metadata.Filename != "jan.csv" {
+		t.Fatalf("incorrect tasks: %+v", page.Tasks)
+	}
+
+	var ids []string
+	path := "/tasks?sort=-created&limit=2"
+	for {
+		v1Request(http.MethodGet, path, nil, &page, ts, t)
+		for _, s := range page.Tasks {
+			ids = append(ids, s.ID)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		path = "/tasks?sort=-created&limit=2&cursor=" + page.NextCursor
+		page.NextCursor = ""
+	}
+	if len(ids) != 3 || ids[1] != b || ids[2] != a.ID {
+		t.Fatalf("incorrect pages: %v", ids)
+	}
+
+	for _, query := range []string{"sort=size", "limit=0", "limit=x", "createdAfter=yesterday", "label=team", "label=team=a,b", "cursor=x"} {
+		if resp := v1Request(http.MethodGet, "/tasks?"+query, nil, nil, ts, t); resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("%s: bad status: %s", query, resp.Status)
+		}
+	}
+}
+
+func TestV1Metadata(t *testing.T) {
+	ts := setupServer(t)
+
+	id := uploadCSV(sampleCSV, map[string]string{"processor": "test-counter", "labels": "team=billing,env=prod", "actor": "alice"}, ts, t)
+
+	var s snapshotV1
+	v1Request(http.MethodGet, "/tasks/"+id, nil, &s, ts, t)
+
+	sum := sha256.Sum256([]byte(sampleCSV))
+	m := s.Metadata
+	if m.Filename != "test.csv" || m.Size != int64(len(sampleCSV)) || m.SHA256 != hex.EncodeToString(sum[:]) {
+		t.Fatalf("incorrect file metadata: %+v", m)
+	}
+	if m.ContentType != "application/octet-stream" || m.Uploader != "alice" || m.CreatedAt.IsZero() {
+		t.Fatalf("incorrect upload metadata: %+v", m)
+	}
+
+	body := map[string]interface{}{"labels": map[string]interface{}{"team": "search", "env": nil}}
+	s = snapshotV1{}
+	if resp := v1Request(http.MethodPatch, "/tasks/"+id, body, &s, ts, t); resp.StatusCode != http.StatusOK {
+		t.Fatalf("bad status: %s", resp.Status)
+	}
+	if labels := s.Metadata.Labels; len(labels) != 1 || labels["team"] != "search" {
+		t.Fatalf("incorrect labels: %v", labels)
+	}
+
+	body = map[string]interface{}{"labels": map[string]string{"team": "a,b"}}
+	if resp := v1Request(http.MethodPatch, "/tasks/"+id, body, nil, ts, t); resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("bad status for invalid label: %s", resp.Status)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	v1Request(http.MethodGet, "/tasks/"+id, nil, &s, ts, t)
+	if s.Metadata.StartedAt == nil || s.Metadata.FinishedAt == nil {
+		t.Fatalf("incorrect timestamps: %+v", s.Metadata)
+	}
+}
+
+func TestV1Start(t *testing.T) {
+	ts := setupServer(t)
+
+	s := createTaskV1(map[string]interface{}{
+		"content": sampleCSV,
+		"config":  map[string]interface{}{"processor": "test-counter"},
+		"start":   false,
+	}, ts, t)
+	if s.Status != task.TaskNotStarted {
+		t.Fatalf("expected not-started, got: %s", s.Status)
+	}
+
+	var control struct {
+		Status task.Status `json:"status"`
+	}
+	v1Request(http.MethodPost, "/tasks/"+s.ID+":start", map[string]string{"wait": "2s"}, &control, ts, t)
+	if control.Status == task.TaskNotStarted {
+		t.Fatalf("expected the task to be started, got: %s", control.Status)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	v1Request(http.MethodGet, "/tasks/"+s.ID, nil, &s, ts, t)
+	if s.Status != task.TaskFinished {
+		t.Fatalf("expected finished, got: %s", s.Status)
+	}
+}
+
+func TestV1Preview(t *testing.T) {
+	ts := setupServer(t)
+
+	s := createTaskV1(map[string]interface{}{
+		"content": sampleCSV,
+		"config":  map[string]interface{}{"processor": "test-slow", "dialect": map[string]interface{}{"header": true}},
+		"start":   false,
+	}, ts, t)
+
+	var p task.Preview
+	v1Request(http.MethodGet, "/tasks/"+s.ID+"/preview?records=2&dryRun=true", nil, &p, ts, t)
+	if len(p.Records) != 2 || !p.More || len(p.Columns) != 2 || p.Columns[0].Type != task.ColumnInteger {
+		t.Fatalf("incorrect preview: %+v", p)
+	}
+	if p.DryRun == nil || len(p.DryRun.Results) != 2 || p.DryRun.Results[1].Output[1] != "y" {
+		t.Fatalf("incorrect dry run: %+v", p.DryRun)
+	}
+
+	v1Request(http.MethodGet, "/tasks/"+s.ID, nil, &s, ts, t)
+	if s.Status != task.TaskNotStarted {
+		t.Fatalf("expected the task to be left not started, got: %s", s.Status)
+	}
+
+	for _, query := range []string{"records=0", "records=1001", "dryRun=maybe"} {
+		if resp := v1Request(http.MethodGet, "/tasks/"+s.ID+"/preview?"+query, nil, nil, ts, t); resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("%s: bad status: %s", query, resp.Status)
+		}
+	}
+	if resp := v1Request(http.MethodPost, "/tasks/"+s.ID+"/preview", nil, nil, ts, t); resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Fatalf("bad status for POST: %s", resp.Status)
+	}
+}
+
+func TestV1Rerun(t *testing.T) {
+	ts := setupServer(t)
+
+	parent := createTaskV1(map[string]interface{}{
+		"filename": "jan.csv",
+		"content":  sampleCSV,
+		"config":   map[string]interface{}{"processor": "test-slow", "timeout": "1m"},
+		"labels":   map[string]string{"team": "billing"},
+	}, ts, t)
+
+	// Tasks can't be re-run while running.
+	if resp := v1Request(http.MethodPost, "/tasks/"+parent.ID+"/rerun", nil, nil, ts, t); resp.StatusCode != http.StatusConflict {
+		t.Fatalf("bad status for running task: %s", resp.Status)
+	}
+
+	v1Request(http.MethodPost, "/tasks/"+parent.ID+":terminate", map[string]string{"wait": "2s"}, nil, ts, t)
+
+	body := map[string]interface{}{
+		"config": map[string]interface{}{"processor": "test-counter"},
+		"actor":  "alice",
+	}
+	var child snapshotV1
+	resp := v1Request(http.MethodPost, "/tasks/"+parent.ID+"/rerun", body, &child, ts, t)
+	if resp.StatusCode != http.StatusCreated || resp.Header.Get("Location") != v1Prefix+"/tasks/"+child.ID {
+		t.Fatalf("bad response: %s, location: %q", resp.Status, resp.Header.Get("Location"))
+	}
+	if child.Config.Processor != "test-counter" || child.Config.Timeout != "1m0s" {
+		t.Fatalf("incorrect config: %+v", child.Config)
+	}
+	m := child.Metadata
+	if m.Parent != parent.ID || m.Filename != "jan.csv" || m.Labels["team"] != "billing" || m.SHA256 != parent.Metadata.SHA256 || m.Uploader != "alice" {
+		t.Fatalf("incorrect metadata: %+v", m)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	if n := countedRecords(child.ID, t); n != 4 {
+		t.Fatalf("expected 4 records processed, got: %d", n)
+	}
+
+	var page struct {
+		Tasks []snapshotV1 `json:"tasks"`
+	}
+	v1Request(http.MethodGet, "/tasks?parent="+parent.ID, nil, &page, ts, t)
+	if len(page.Tasks) != 1 || page.Tasks[0].ID != child.ID || page.Tasks[0].Status != task.TaskFinished {
+		t.Fatalf("incorrect children: %+v", page.Tasks)
+	}
+
+	// The parent can be deleted without affecting its children.
+	v1Request(http.MethodDelete, "/tasks/"+parent.ID, nil, nil, ts, t)
+	body = map[string]interface{}{"labels": map[string]string{}, "start": false}
+	child = snapshotV1{}
+	v1Request(http.MethodPost, "/tasks/"+page.Tasks[0].ID+"/rerun", body, &child, ts, t)
+	if child.Status != task.TaskNotStarted || len(child.Metadata.Labels) != 0 || child.Metadata.Parent != page.Tasks[0].ID {
+		t.Fatalf("incorrect re-run of the child: %+v", child)
+	}
+
+	body = map[string]interface{}{"config": map[string]interface{}{"output": "xml"}}
+	if resp := v1Request(http.MethodPost, "/tasks/"+page.Tasks[0].ID+"/rerun", body, nil, ts, t); resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("bad status for invalid config: %s", resp.Status)
+	}
+	body = map[string]interface{}{"config": map[string]interface{}{"unknown": true}}
+	if resp := v1Request(http.MethodPost, "/tasks/"+page.Tasks[0].ID+"/rerun", body, nil, ts, t); resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("bad status for unknown option: %s", resp.Status)
+	}
+}
+
+func TestV1Breakpoints(t *testing.T) {
+	ts := setupServer(t)
+
+	s := createTaskV1(map[string]interface{}{
+		"content": sampleCSV,
+		"config": map[string]interface{}{
+			"processor":   "test-counter",
+			"dialect":     map[string]interface{}{"header": true},
+			"breakpoints": []map[string]interface{}{{"row": 2}},
+		},
+	}, ts, t)
+
+	time.Sleep(100 * time.Millisecond)
+
+	v1Request(http.MethodGet, "/tasks/"+s.ID, nil, &s, ts, t)
+	if s.Status != task.TaskPaused || len(s.Breakpoints) != 1 {
+		t.Fatalf("expected to be paused at the breakpoint, got: %+v", s)
+	}
+	if n := countedRecords(s.ID, t); n != 1 {
+		t.Fatalf("expected 1 record processed, got: %d", n)
+	}
+
+	v1Request(http.MethodPost, "/tasks/"+s.ID+":step", nil, nil, ts, t)
+	time.Sleep(100 * time.Millisecond)
+
+	v1Request(http.MethodGet, "/tasks/"+s.ID, nil, &s, ts, t)
+	if s.Status != task.TaskPaused {
+		t.Fatalf("expected to be paused after a step, got: %s", s.Status)
+	}
+	if n := countedRecords(s.ID, t); n != 2 {
+		t.Fatalf("expected 2 records processed, got: %d", n)
+	}
+
+	if resp := v1Request(http.MethodPatch, "/tasks/"+s.ID, map[string]interface{}{"breakpoints": []map[string]interface{}{{"value": "x"}}}, nil, ts, t); resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("bad status for an invalid breakpoint: %s", resp.Status)
+	}
+	if resp := v1Request(http.MethodPatch, "/tasks/"+s.ID, map[string]interface{}{"breakpoints": []interface{}{}, "labels": map[string]string{"team": "a b"}}, nil, ts, t); resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("bad status for an invalid label: %s", resp.Status)
+	}
+	v1Request(http.MethodGet, "/tasks/"+s.ID, nil, &s, ts, t)
+	if len(s.Breakpoints) != 1 || len(s.Metadata.Labels) != 0 {
+		t.Fatalf("expected a rejected update to change nothing, got: %+v", s)
+	}
+
+	var updated snapshotV1
+	v1Request(http.MethodPatch, "/tasks/"+s.ID, map[string]interface{}{"breakpoints": []interface{}{}}, &updated, ts, t)
+	if len(updated.Breakpoints) != 0 || len(updated.Config.Breakpoints) != 1 {
+		t.Fatalf("expected the breakpoints to be removed, got: %+v", updated)
+	}
+
+	v1Request(http.MethodPost, "/tasks/"+s.ID+":resume", nil, nil, ts, t)
+	time.Sleep(100 * time.Millisecond)
+
+	v1Request(http.MethodGet, "/tasks/"+s.ID, nil, &s, ts, t)
+	if s.Status != task.TaskFinished {
+		t.Fatalf("expected finished, got: %s", s.Status)
+	}
+}
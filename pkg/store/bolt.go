@@ -0,0 +1,179 @@
+package store
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/prmsrswt/pipeline/pkg/task"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// ErrNewerSchema is returned when the database was written by a newer version of the pipeline.
+var ErrNewerSchema = errors.New("database schema is newer than supported")
+
+var (
+	metaBucket  = []byte("meta")
+	tasksBucket = []byte("tasks")
+	versionKey  = []byte("version")
+)
+
+// migrations upgrade the schema of the database one version at a time, the
+// version of a database being the number of migrations applied to it.
+// Released migrations must never change, new ones are appended.
+var migrations = []func(tx *bolt.Tx) error{
+	// 1: the records of the tasks are kept as JSON by task ID.
+	func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(tasksBucket)
+		return err
+	},
+}
+
+// BoltStore is a TaskStore keeping the tasks in memory, and persisting their
+// records in a bbolt database so that they survive restarts.
+type BoltStore struct {
+	*MemoryStore
+	db *bolt.DB
+}
+
+// OpenBoltStore opens the database at the given path, creating it if needed,
+// and migrates it to the latest schema.
+func OpenBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := migrate(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &BoltStore{MemoryStore: NewMemoryStore(), db: db}, nil
+}
+
+// migrate applies the migrations the database is missing, all at once.
+func migrate(db *bolt.DB) error {
+	return db.Update(func(tx *bolt.Tx) error {
+		meta, err := tx.CreateBucketIfNotExists(metaBucket)
+		if err != nil {
+			return err
+		}
+
+		var version uint64
+		if v := meta.Get(versionKey); v != nil {
+			version = binary.BigEndian.Uint64(v)
+		}
+		if version > uint64(len(migrations)) {
+			return fmt.Errorf("%w: version %d", ErrNewerSchema, version)
+		}
+
+		for v := version; v < uint64(len(migrations)); v++ {
+			if err := migrations[v](tx); err != nil {
+				return fmt.Errorf("migrating schema to version %d: %w", v+1, err)
+			}
+			log.Printf("[store] migrated schema to version %d\n", v+1)
+		}
+
+		b := make([]byte, 8)
+		binary.BigEndian.PutUint64(b, uint64(len(migrations)))
+		return meta.Put(versionKey, b)
+	})
+}
+
+// Close closes the database.
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
+
+// Put stores the task and persists its record, which is then kept up to
+// date as the task changes.
+func (s *BoltStore) Put(t *task.Task) error {
+	if err := s.MemoryStore.Put(t); err != nil {
+		return err
+	}
+
+	t.OnChange(s.save)
+	if err := s.persist(t); err != nil {
+		t.OnChange(nil)
+		s.MemoryStore.Delete(t.ID)
+		return err
+	}
+	return nil
+}
+
+// Delete removes the task with the given ID along with its record, or returns ErrNotFound.
+func (s *BoltStore) Delete(id string) error {
+	t, err := s.MemoryStore.Get(id)
+	if err != nil {
+		return err
+	}
+
+	t.OnChange(nil)
+	if err := s.MemoryStore.Delete(id); err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(tasksBucket).Delete([]byte(id))
+	})
+}
+
+// Restore rebuilds the tasks from their records, see task.RestoreCheckpoint.
+// Tasks which can't be restored are skipped.
+func (s *BoltStore) Restore(sched *task.Scheduler) error {
+	var records []task.Checkpoint
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(tasksBucket).ForEach(func(k, v []byte) error {
+			var cp task.Checkpoint
+			if err := json.Unmarshal(v, &cp); err != nil {
+				log.Printf("[error] decoding record of task %s: %v\n", k, err)
+				return nil
+			}
+			records = append(records, cp)
+			return nil
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, cp := range records {
+		t, err := task.RestoreCheckpoint(cp, sched)
+		if err != nil {
+			log.Printf("[error] restoring task %s: %v\n", cp.ID, err)
+			continue
+		}
+		if err := s.Put(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// save persists the record of the task, failures are only logged.
+func (s *BoltStore) save(t *task.Task) {
+	if err := s.persist(t); err != nil {
+		log.Printf("[%s] saving record: %v\n", t.ID, err)
+	}
+}
+
+// persist writes the record of the task, unless it was deleted meanwhile.
+func (s *BoltStore) persist(t *task.Task) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if stored, err := s.MemoryStore.Get(t.ID); err != nil || stored != t {
+			return nil
+		}
+
+		b, err := json.Marshal(t.Record())
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(tasksBucket).Put([]byte(t.ID), b)
+	})
+}
@@ -0,0 +1,127 @@
+package store
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prmsrswt/pipeline/pkg/task"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+func openBoltStore(path string, t *testing.T) *BoltStore {
+	s, err := OpenBoltStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestBoltStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pipeline.db")
+	s := openBoltStore(path, t)
+
+	a, b := newTask("a", t), newTask("b", t)
+	a.Config.ErrorBudget = 5
+	s.Put(a)
+	s.Put(b)
+	if err := a.UpdateLabels(map[string]string{"team": "billing"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Delete("b"); err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+
+	s = openBoltStore(path, t)
+	defer s.Close()
+	if err := s.Restore(task.NewScheduler(0, false)); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.Get("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status() != task.TaskNotStarted || got.Config.ErrorBudget != 5 || got.Metadata().Labels["team"] != "billing" {
+		t.Fatalf("incorrect restored task: %+v", got.Record())
+	}
+	if _, err := s.Get("b"); err != ErrNotFound {
+		t.Fatalf("expected deleted task to be gone, got: %v", err)
+	}
+}
+
+func TestBoltStoreRecordsChanges(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "a.csv")
+	if err := ioutil.WriteFile(file, []byte("1,x\n2,y\n3,z\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tk, err := task.NewTask("a", file, task.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(dir, "pipeline.db")
+	s := openBoltStore(path, t)
+	s.Put(tk)
+
+	sched := task.NewScheduler(0, false)
+	if err := sched.Submit(tk, task.Cause{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tk.Terminate(task.Cause{}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-tk.Settled():
+	case <-time.After(2 * time.Second):
+		t.Fatal("task didn't terminate")
+	}
+	s.Close()
+
+	s = openBoltStore(path, t)
+	defer s.Close()
+	if err := s.Restore(sched); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.Get("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status() != task.TaskTerminated {
+		t.Fatalf("expected terminated, got: %s", got.Status())
+	}
+	if events := got.Record().Events; len(events) < 3 {
+		t.Fatalf("expected the history of the task to be kept, got: %v", events)
+	}
+}
+
+func TestBoltStoreMigrations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pipeline.db")
+	openBoltStore(path, t).Close()
+
+	defer func(m []func(*bolt.Tx) error) { migrations = m }(migrations)
+
+	// Only the migrations the database is missing are applied.
+	applied := 0
+	migrations = append(migrations, func(*bolt.Tx) error {
+		applied++
+		return nil
+	})
+	openBoltStore(path, t).Close()
+	openBoltStore(path, t).Close()
+	if applied != 1 {
+		t.Fatalf("expected the new migration to be applied once, got: %d", applied)
+	}
+
+	// Databases written by newer versions are left alone.
+	migrations = migrations[:1]
+	if _, err := OpenBoltStore(path); !errors.Is(err, ErrNewerSchema) {
+		t.Fatalf("expected newer schema error, got: %v", err)
+	}
+}
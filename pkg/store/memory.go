@@ -0,0 +1,125 @@
+package store
+
+import (
+	"context"
+	"log"
+	"sort"
+	"sync"
+
+	"github.com/prmsrswt/pipeline/pkg/task"
+)
+
+// watchBuffer is the number of events a watcher may lag behind before it misses some.
+const watchBuffer = 64
+
+// MemoryStore is a TaskStore keeping the tasks in memory.
+type MemoryStore struct {
+	mutex    sync.RWMutex
+	tasks    map[string]*task.Task
+	watchers map[chan Event]struct{}
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		tasks:    make(map[string]*task.Task),
+		watchers: make(map[chan Event]struct{}),
+	}
+}
+
+// Get returns the task with the given ID, or ErrNotFound.
+func (s *MemoryStore) Get(id string) (*task.Task, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	t, ok := s.tasks[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return t, nil
+}
+
+// Put stores the task, replacing any task with the same ID.
+func (s *MemoryStore) Put(t *task.Task) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.tasks[t.ID] = t
+	s.broadcast(Event{Type: EventPut, ID: t.ID, Task: t})
+	return nil
+}
+
+// List returns all the tasks, oldest first.
+func (s *MemoryStore) List() ([]*task.Task, error) {
+	s.mutex.RLock()
+	tasks := make([]*task.Task, 0, len(s.tasks))
+	for _, t := range s.tasks {
+		tasks = append(tasks, t)
+	}
+	s.mutex.RUnlock()
+
+	sortTasks(tasks)
+	return tasks, nil
+}
+
+// Delete removes the task with the given ID, or returns ErrNotFound.
+func (s *MemoryStore) Delete(id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.tasks[id]; !ok {
+		return ErrNotFound
+	}
+	delete(s.tasks, id)
+	s.broadcast(Event{Type: EventDelete, ID: id})
+	return nil
+}
+
+// Watch returns a channel receiving the changes made to the store until the
+// context is done. Watchers lagging more than a few events behind miss the
+// following ones.
+func (s *MemoryStore) Watch(ctx context.Context) <-chan Event {
+	ch := make(chan Event, watchBuffer)
+
+	s.mutex.Lock()
+	s.watchers[ch] = struct{}{}
+	s.mutex.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		s.mutex.Lock()
+		delete(s.watchers, ch)
+		close(ch)
+		s.mutex.Unlock()
+	}()
+
+	return ch
+}
+
+// broadcast sends the event to all the watchers. The caller must hold the mutex.
+func (s *MemoryStore) broadcast(e Event) {
+	for ch := range s.watchers {
+		select {
+		case ch <- e:
+		default:
+			log.Printf("[store] watcher missed %s event of %s\n", e.Type, e.ID)
+		}
+	}
+}
+
+// sortTasks sorts the tasks by creation time, then ID.
+func sortTasks(tasks []*task.Task) {
+	created := make(map[*task.Task]int64, len(tasks))
+	for _, t := range tasks {
+		created[t] = t.Timeline().CreatedAt.UnixNano()
+	}
+
+	sort.Slice(tasks, func(i, j int) bool {
+		a, b := tasks[i], tasks[j]
+		if created[a] != created[b] {
+			return created[a] < created[b]
+		}
+		return a.ID < b.ID
+	})
+}
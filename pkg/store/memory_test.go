@@ -0,0 +1,109 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prmsrswt/pipeline/pkg/task"
+)
+
+func newTask(id string, t *testing.T) *task.Task {
+	tk, err := task.NewTask(id, id+".csv", task.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tk
+}
+
+func TestMemoryStore(t *testing.T) {
+	s := NewMemoryStore()
+
+	if _, err := s.Get("a"); err != ErrNotFound {
+		t.Fatalf("expected not found, got: %v", err)
+	}
+
+	a, b := newTask("a", t), newTask("b", t)
+	s.Put(b)
+	s.Put(a)
+
+	if got, err := s.Get("a"); err != nil || got != a {
+		t.Fatalf("incorrect task: %v, %v", got, err)
+	}
+
+	// Tasks are listed oldest first, whatever the order they were put in.
+	tasks, err := s.List()
+	if err != nil || len(tasks) != 2 || tasks[0] != a || tasks[1] != b {
+		t.Fatalf("incorrect list: %v, %v", tasks, err)
+	}
+
+	if err := s.Delete("a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Delete("a"); err != ErrNotFound {
+		t.Fatalf("expected not found, got: %v", err)
+	}
+	if tasks, _ := s.List(); len(tasks) != 1 {
+		t.Fatalf("incorrect list after delete: %v", tasks)
+	}
+}
+
+func TestMemoryStoreWatch(t *testing.T) {
+	s := NewMemoryStore()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := s.Watch(ctx)
+
+	a := newTask("a", t)
+	s.Put(a)
+	s.Delete("a")
+
+	for _, expected := range []Event{{Type: EventPut, ID: "a", Task: a}, {Type: EventDelete, ID: "a"}} {
+		select {
+		case e := <-events:
+			if e != expected {
+				t.Fatalf("incorrect event. expected: %+v; got: %+v", expected, e)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for %s event", expected.Type)
+		}
+	}
+
+	cancel()
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Fatal("expected no more events")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the channel to be closed")
+	}
+}
+
+func TestMemoryStoreConcurrent(t *testing.T) {
+	s := NewMemoryStore()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.Watch(ctx)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+
+			s.Put(newTask(id, t))
+			s.Get(id)
+			s.List()
+			s.Delete(id)
+		}(fmt.Sprint(i))
+	}
+	wg.Wait()
+
+	if tasks, _ := s.List(); len(tasks) != 0 {
+		t.Fatalf("expected no tasks left: %v", tasks)
+	}
+}
@@ -0,0 +1,228 @@
+package store
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/prmsrswt/pipeline/pkg/task"
+)
+
+// Limits of the number of tasks in a page of search results.
+const (
+	DefaultLimit = 50
+	MaxLimit     = 500
+)
+
+// Fields tasks can be sorted by.
+const (
+	SortCreated  = "created"
+	SortFilename = "filename"
+	SortStatus   = "status"
+)
+
+// ErrInvalidQuery is returned when a search query can't be run.
+var ErrInvalidQuery = errors.New("invalid query")
+
+// Query selects tasks and orders them.
+type Query struct {
+	// Statuses matches the tasks in any of them, all tasks if empty.
+	Statuses []task.Status
+	// CreatedAfter and CreatedBefore bound the creation time of the tasks when
+	// not zero, the former being inclusive and the latter exclusive.
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	// Filename is a pattern the original filename must match, see path.Match.
+	Filename string
+	// Selector matches the labels of the tasks.
+	Selector task.Selector
+	// Parent matches the tasks re-running or replaying this task, if not empty.
+	Parent string
+	// Sort is the field the tasks are ordered by, SortCreated if empty.
+	// Ties are broken by ID.
+	Sort string
+	// Desc reverses the order.
+	Desc bool
+	// Limit is the maximum number of tasks returned, DefaultLimit if zero.
+	Limit int
+	// Cursor resumes a previous search right after the page it ended.
+	Cursor string
+}
+
+// Page is a page of search results.
+type Page struct {
+	Tasks []task.Snapshot `json:"tasks"`
+	// NextCursor resumes the search after this page, it is empty on the last one.
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// cursor is the position of the last task of a page, in the order of the query.
+type cursor struct {
+	Sort string `json:"s"`
+	Desc bool   `json:"d,omitempty"`
+	Key  string `json:"k"`
+	ID   string `json:"i"`
+}
+
+// Search returns the page of the tasks of the store matching the query.
+// The tasks are read as snapshots, so the page reflects them at a point in time.
+func Search(s TaskStore, q Query) (Page, error) {
+	if q.Sort == "" {
+		q.Sort = SortCreated
+	}
+	if q.Limit == 0 {
+		q.Limit = DefaultLimit
+	}
+	if err := q.validate(); err != nil {
+		return Page{}, err
+	}
+
+	after, err := q.after()
+	if err != nil {
+		return Page{}, err
+	}
+
+	tasks, err := s.List()
+	if err != nil {
+		return Page{}, err
+	}
+
+	var matches []task.Snapshot
+	for _, t := range tasks {
+		if snap := t.Snapshot(); q.Match(snap) {
+			matches = append(matches, snap)
+		}
+	}
+
+	keys := make(map[string]string, len(matches))
+	for _, snap := range matches {
+		keys[snap.ID] = sortKey(snap, q.Sort)
+	}
+	less := func(aKey, aID, bKey, bID string) bool {
+		if aKey == bKey {
+			aKey, bKey = aID, bID
+		}
+		if q.Desc {
+			return aKey > bKey
+		}
+		return aKey < bKey
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		return less(keys[a.ID], a.ID, keys[b.ID], b.ID)
+	})
+
+	start := 0
+	if after != nil {
+		start = sort.Search(len(matches), func(i int) bool {
+			return less(after.Key, after.ID, keys[matches[i].ID], matches[i].ID)
+		})
+	}
+
+	page := Page{Tasks: matches[start:]}
+	if len(page.Tasks) > q.Limit {
+		page.Tasks = page.Tasks[:q.Limit]
+		last := page.Tasks[q.Limit-1]
+		page.NextCursor = encodeCursor(cursor{Sort: q.Sort, Desc: q.Desc, Key: keys[last.ID], ID: last.ID})
+	}
+	if page.Tasks == nil {
+		page.Tasks = []task.Snapshot{}
+	}
+	return page, nil
+}
+
+// Match reports whether the task matches the filters of the query.
+func (q Query) Match(s task.Snapshot) bool {
+	if len(q.Statuses) > 0 && !hasStatus(q.Statuses, s.Status) {
+		return false
+	}
+
+	created := s.Timeline.CreatedAt
+	if !q.CreatedAfter.IsZero() && created.Before(q.CreatedAfter) {
+		return false
+	}
+	if !q.CreatedBefore.IsZero() && !created.Before(q.CreatedBefore) {
+		return false
+	}
+
+	if q.Filename != "" {
+		if ok, _ := path.Match(q.Filename, s.Metadata.Filename); !ok {
+			return false
+		}
+	}
+
+	if q.Parent != "" && s.Metadata.Parent != q.Parent {
+		return false
+	}
+
+	return q.Selector.Matches(s.Metadata.Labels)
+}
+
+func (q Query) validate() error {
+	switch q.Sort {
+	case SortCreated, SortFilename, SortStatus:
+	default:
+		return fmt.Errorf("%w: unknown sort field %q", ErrInvalidQuery, q.Sort)
+	}
+
+	if q.Limit < 0 || q.Limit > MaxLimit {
+		return fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidQuery, MaxLimit)
+	}
+
+	if _, err := path.Match(q.Filename, ""); err != nil {
+		return fmt.Errorf("%w: filename pattern: %v", ErrInvalidQuery, err)
+	}
+	return nil
+}
+
+// after returns the position the page starts after, if the query has a cursor.
+func (q Query) after() (*cursor, error) {
+	if q.Cursor == "" {
+		return nil, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(q.Cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidQuery)
+	}
+
+	var c cursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidQuery)
+	}
+	if c.Sort != q.Sort || c.Desc != q.Desc {
+		return nil, fmt.Errorf("%w: cursor of another sort order", ErrInvalidQuery)
+	}
+	return &c, nil
+}
+
+func encodeCursor(c cursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// sortKey returns the value of the field of the task, as a string ordered
+// like the field.
+func sortKey(s task.Snapshot, field string) string {
+	switch field {
+	case SortFilename:
+		return s.Metadata.Filename
+	case SortStatus:
+		return string(s.Status)
+	}
+	// A fixed width layout so that times compare as strings.
+	return s.Timeline.CreatedAt.UTC().Format("2006-01-02T15:04:05.000000000Z")
+}
+
+func hasStatus(statuses []task.Status, status task.Status) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,158 @@
+package store
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prmsrswt/pipeline/pkg/task"
+)
+
+// searchStore returns a store with tasks a to e, created in this order.
+func searchStore(t *testing.T) *MemoryStore {
+	s := NewMemoryStore()
+
+	for _, tc := range []struct {
+		id, filename string
+		status       task.Status
+		labels       map[string]string
+		parent       string
+	}{
+		{"a", "jan.csv", task.TaskFinished, map[string]string{"team": "billing"}, ""},
+		{"b", "feb.csv", task.TaskPaused, map[string]string{"team": "billing", "env": "prod"}, ""},
+		{"c", "feb.tsv", task.TaskGotError, map[string]string{"team": "search"}, ""},
+		{"d", "mar.csv", task.TaskPaused, nil, "c"},
+		{"e", "apr.csv", task.TaskGotError, map[string]string{"team": "billing"}, "a"},
+	} {
+		tk := newTask(tc.id, t)
+		tk.State = tc.status
+		if err := tk.SetMetadata(task.Metadata{Filename: tc.filename, Labels: tc.labels, Parent: tc.parent}); err != nil {
+			t.Fatal(err)
+		}
+		s.Put(tk)
+		time.Sleep(time.Millisecond)
+	}
+	return s
+}
+
+func searchIDs(s TaskStore, q Query, t *testing.T) ([]string, string) {
+	t.Helper()
+
+	page, err := Search(s, q)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := []string{}
+	for _, snap := range page.Tasks {
+		ids = append(ids, snap.ID)
+	}
+	return ids, page.NextCursor
+}
+
+func selector(s string, t *testing.T) task.Selector {
+	sel, err := task.ParseSelector(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sel
+}
+
+func TestSearchFilters(t *testing.T) {
+	s := searchStore(t)
+	c, _ := s.Get("c")
+	created := c.Timeline().CreatedAt
+
+	tests := []struct {
+		name     string
+		query    Query
+		expected string
+	}{
+		{"all", Query{}, "abcde"},
+		{"status", Query{Statuses: []task.Status{task.TaskPaused}}, "bd"},
+		{"statuses", Query{Statuses: []task.Status{task.TaskPaused, task.TaskGotError}}, "bcde"},
+		{"created after", Query{CreatedAfter: created}, "cde"},
+		{"created before", Query{CreatedBefore: created}, "ab"},
+		{"filename", Query{Filename: "feb.*"}, "bc"},
+		{"labels", Query{Selector: selector("team=billing", t)}, "abe"},
+		{"all labels", Query{Selector: selector("team=billing,env=prod", t)}, "b"},
+		{"excluded labels", Query{Selector: selector("team=billing,env!=prod", t)}, "ae"},
+		{"parent", Query{Parent: "c"}, "d"},
+		{"combined", Query{Statuses: []task.Status{task.TaskGotError}, Selector: selector("team=billing", t)}, "e"},
+		{"sort desc", Query{Desc: true}, "edcba"},
+		{"sort filename", Query{Sort: SortFilename}, "ebcad"},
+		{"sort status", Query{Sort: SortStatus, Desc: true}, "dbeca"},
+	}
+
+	for _, tc := range tests {
+		ids, _ := searchIDs(s, tc.query, t)
+		got := ""
+		for _, id := range ids {
+			got += id
+		}
+		if got != tc.expected {
+			t.Errorf("%s: expected %s, got: %s", tc.name, tc.expected, got)
+		}
+	}
+}
+
+func TestSearchPagination(t *testing.T) {
+	s := searchStore(t)
+
+	for _, desc := range []bool{false, true} {
+		q := Query{Sort: SortFilename, Desc: desc, Limit: 2}
+
+		var got []string
+		for pages := 0; ; pages++ {
+			if pages > 3 {
+				t.Fatal("too many pages")
+			}
+
+			ids, next := searchIDs(s, q, t)
+			got = append(got, ids...)
+			if next == "" {
+				break
+			}
+			q.Cursor = next
+
+			// Tasks added meanwhile don't shift the pages.
+			if pages == 0 {
+				added := newTask("f", t)
+				added.SetMetadata(task.Metadata{Filename: "aaa.csv"})
+				s.Put(added)
+			}
+		}
+
+		expected := []string{"e", "b", "c", "a", "d"}
+		if desc {
+			expected = []string{"d", "a", "c", "b", "e", "f"}
+		}
+		if len(got) != len(expected) {
+			t.Fatalf("desc %v: expected %v, got: %v", desc, expected, got)
+		}
+		for i := range got {
+			if got[i] != expected[i] {
+				t.Fatalf("desc %v: expected %v, got: %v", desc, expected, got)
+			}
+		}
+		s.Delete("f")
+	}
+}
+
+func TestSearchInvalid(t *testing.T) {
+	s := searchStore(t)
+
+	_, next := searchIDs(s, Query{Limit: 1}, t)
+
+	for _, q := range []Query{
+		{Sort: "size"},
+		{Limit: MaxLimit + 1},
+		{Filename: "["},
+		{Cursor: "not a cursor"},
+		{Cursor: next, Desc: true},
+	} {
+		if _, err := Search(s, q); !errors.Is(err, ErrInvalidQuery) {
+			t.Errorf("%+v: expected invalid query, got: %v", q, err)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+// Package store keeps track of the tasks known to the pipeline.
+package store
+
+import (
+	"context"
+	"errors"
+
+	"github.com/prmsrswt/pipeline/pkg/task"
+)
+
+// ErrNotFound is returned when no task is stored with the requested ID.
+var ErrNotFound = errors.New("task not found")
+
+// EventType is the kind of change made to a store.
+type EventType string
+
+// Various possible changes to a store.
+const (
+	EventPut    EventType = "put"
+	EventDelete EventType = "delete"
+)
+
+// Event is a change made to a store.
+type Event struct {
+	Type EventType
+	ID   string
+	// Task is the stored task, nil for deletions.
+	Task *task.Task
+}
+
+// TaskStore keeps the tasks by their ID. Implementations must be safe for
+// concurrent use.
+type TaskStore interface {
+	// Get returns the task with the given ID, or ErrNotFound.
+	Get(id string) (*task.Task, error)
+	// Put stores the task, replacing any task with the same ID.
+	Put(t *task.Task) error
+	// List returns all the tasks, oldest first.
+	List() ([]*task.Task, error)
+	// Delete removes the task with the given ID, or returns ErrNotFound.
+	Delete(id string) error
+	// Watch returns a channel receiving the changes made to the store until
+	// the context is done, at which point the channel is closed.
+	Watch(ctx context.Context) <-chan Event
+}
+
+// Restorer is implemented by durable stores, which bring their tasks back after a restart.
+type Restorer interface {
+	// Restore rebuilds the stored tasks, queueing them in the scheduler as needed.
+	Restore(s *task.Scheduler) error
+}
@@ -0,0 +1,127 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+)
+
+// ErrInvalidBreakpoint is returned when a breakpoint doesn't tell which records it matches.
+var ErrInvalidBreakpoint = errors.New("invalid breakpoint")
+
+// Breakpoint pauses a task before it processes the matching record, e.g. to
+// look into what its processor does with it. A breakpoint with both a row and
+// a field matches the record at the row only if its field has the value.
+type Breakpoint struct {
+	// Row matches the record at this row, numbered like the rows of errors.
+	Row int64 `json:"row,omitempty"`
+	// Field and Value match the records whose field has the value. The field
+	// is the name of a column of the header, or the number of the column
+	// counted from 1.
+	Field string `json:"field,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// ValidateBreakpoints returns an error wrapping ErrInvalidBreakpoint if a
+// breakpoint has a negative row, or neither a row nor a field.
+func ValidateBreakpoints(bps []Breakpoint) error {
+	for _, bp := range bps {
+		if bp.Row < 0 {
+			return fmt.Errorf("%w: negative row %d", ErrInvalidBreakpoint, bp.Row)
+		}
+		if bp.Row == 0 && bp.Field == "" {
+			return fmt.Errorf("%w: a row or field is required", ErrInvalidBreakpoint)
+		}
+	}
+	return nil
+}
+
+// matches reports whether the breakpoint matches the record at the row.
+func (bp Breakpoint) matches(row int64, record []string, header Header) bool {
+	if bp.Row > 0 && bp.Row != row {
+		return false
+	}
+	if bp.Field == "" {
+		return true
+	}
+
+	i := header.Index(bp.Field)
+	if i < 0 {
+		n, err := strconv.Atoi(bp.Field)
+		if err != nil || n < 1 {
+			return false
+		}
+		i = n - 1
+	}
+	return i < len(record) && record[i] == bp.Value
+}
+
+// Breakpoints returns the breakpoints of the task.
+func (t *Task) Breakpoints() []Breakpoint {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return append([]Breakpoint(nil), t.breakpoints...)
+}
+
+// SetBreakpoints replaces the breakpoints of the task, from the next record on.
+// It returns an error wrapping ErrInvalidBreakpoint if one of them is invalid.
+func (t *Task) SetBreakpoints(bps []Breakpoint) error {
+	if err := ValidateBreakpoints(bps); err != nil {
+		return err
+	}
+
+	t.mutex.Lock()
+	t.breakpoints = append([]Breakpoint(nil), bps...)
+	onChange := t.onChange
+	t.mutex.Unlock()
+
+	t.saveRecord()
+	if onChange != nil {
+		onChange(t)
+	}
+	return nil
+}
+
+// breakpoint moves the running task to pausing if one of its breakpoints
+// matches the record at the next row, and reports whether it did. It doesn't
+// break twice at the same row, so that the record gets processed once resumed.
+func (t *Task) breakpoint(record []string, header Header) bool {
+	row := t.row() + 1
+
+	t.mutex.Lock()
+	hit := false
+	if row != t.breakRow {
+		for _, bp := range t.breakpoints {
+			if bp.matches(row, record, header) {
+				hit = true
+				break
+			}
+		}
+	}
+	if hit {
+		t.breakRow = row
+	}
+	t.mutex.Unlock()
+
+	if !hit || !t.transition(TaskPausing, systemCause("breakpoint"), TaskRunning) {
+		return false
+	}
+	log.Printf("[%s] breakpoint at row %d\n", t.ID, row)
+	return true
+}
+
+// checkStep moves the running task to pausing once it processed the record
+// it was resumed for by Step.
+func (t *Task) checkStep() {
+	t.mutex.Lock()
+	done := t.stepUntil > 0 && t.record >= t.stepUntil
+	if done {
+		t.stepUntil = 0
+	}
+	t.mutex.Unlock()
+
+	if done && t.transition(TaskPausing, systemCause("step"), TaskRunning) {
+		log.Printf("[%s] pausing after step\n", t.ID)
+	}
+}
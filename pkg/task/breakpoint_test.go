@@ -0,0 +1,97 @@
+package task
+
+import (
+	"errors"
+	"testing"
+)
+
+const breakpointCSV = "id,name\n1,x\n2,y\n3,z\n4,y\n"
+
+// breakpointConfig returns the config of a task with the breakpoints.
+func breakpointConfig(bps []Breakpoint) Config {
+	return Config{Processor: "test-stub", Dialect: Dialect{Header: true}, Breakpoints: bps}
+}
+
+// checkBreak checks that the task paused for the reason after processing n records.
+func checkBreak(tk *Task, n int64, reason string, t *testing.T) {
+	t.Helper()
+
+	waitStatus(tk, TaskPaused, t)
+	if p := tk.Progress(); p.Processed != n {
+		t.Fatalf("expected %d records processed, got: %d", n, p.Processed)
+	}
+	events := tk.Events()
+	if e := events[len(events)-1]; e.Cause.Reason != reason {
+		t.Fatalf("expected to be paused for %q, got: %+v", reason, e)
+	}
+}
+
+func TestBreakpointStep(t *testing.T) {
+	tk := newTestTask("breakpoint", t, withData(breakpointCSV), withConfig(breakpointConfig([]Breakpoint{{Row: 2}})))
+
+	tk.Run(Cause{})
+	checkBreak(tk, 1, "breakpoint", t)
+
+	if err := tk.Step(Cause{}); err != nil {
+		t.Fatal(err)
+	}
+	checkBreak(tk, 2, "pause applied", t)
+
+	if err := tk.Step(Cause{}); err != nil {
+		t.Fatal(err)
+	}
+	checkBreak(tk, 3, "pause applied", t)
+
+	if err := tk.Resume(Cause{}); err != nil {
+		t.Fatal(err)
+	}
+	waitStatus(tk, TaskFinished, t)
+	if p := tk.Progress(); p.Processed != 4 {
+		t.Fatalf("expected all records processed, got: %+v", p)
+	}
+
+	if err := tk.Step(Cause{}); !errors.Is(err, ErrInvalidTransition) {
+		t.Fatalf("expected ErrInvalidTransition when stepping a finished task, got: %v", err)
+	}
+}
+
+func TestBreakpointField(t *testing.T) {
+	tk := newTestTask("breakpoint", t, withData(breakpointCSV), withConfig(breakpointConfig([]Breakpoint{{Field: "name", Value: "y"}})))
+
+	tk.Run(Cause{})
+	checkBreak(tk, 1, "breakpoint", t)
+
+	// Breakpoints changed while paused apply from the next record on.
+	if err := tk.SetBreakpoints([]Breakpoint{{Field: "1", Value: "4"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tk.Resume(Cause{}); err != nil {
+		t.Fatal(err)
+	}
+	checkBreak(tk, 3, "breakpoint", t)
+
+	if s := tk.Snapshot(); len(s.Breakpoints) != 1 || s.Breakpoints[0].Field != "1" || len(s.Config.Breakpoints) != 1 || s.Config.Breakpoints[0].Field != "name" {
+		t.Fatalf("incorrect breakpoints: %+v, configured: %+v", s.Breakpoints, s.Config.Breakpoints)
+	}
+
+	if err := tk.Resume(Cause{}); err != nil {
+		t.Fatal(err)
+	}
+	waitStatus(tk, TaskFinished, t)
+	if p := tk.Progress(); p.Processed != 4 {
+		t.Fatalf("expected all records processed, got: %+v", p)
+	}
+}
+
+func TestBreakpointInvalid(t *testing.T) {
+	for _, bp := range []Breakpoint{{}, {Row: -1}, {Value: "x"}} {
+		if _, err := NewTask("invalid", "invalid.csv", Config{Breakpoints: []Breakpoint{bp}}); !errors.Is(err, ErrInvalidBreakpoint) {
+			t.Fatalf("expected invalid breakpoint for %+v, got: %v", bp, err)
+		}
+	}
+
+	tk := newTestTask("breakpoint", t, withData(breakpointCSV), withConfig(breakpointConfig(nil)))
+	if err := tk.SetBreakpoints([]Breakpoint{{}}); !errors.Is(err, ErrInvalidBreakpoint) {
+		t.Fatalf("expected invalid breakpoint, got: %v", err)
+	}
+}
@@ -0,0 +1,291 @@
+package task
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// CheckpointExt is the extension of checkpoint files, which are kept next to the uploaded file.
+const CheckpointExt = ".checkpoint"
+
+// Checkpoint is the persisted state of a task and its position in the uploaded file.
+type Checkpoint struct {
+	ID       string   `json:"id"`
+	FilePath string   `json:"filePath"`
+	Config   Config   `json:"config"`
+	Metadata Metadata `json:"metadata"`
+	State    Status   `json:"state"`
+	Error    *Error   `json:"error,omitempty"`
+	// Record is the number of records processed so far.
+	Record int64 `json:"record"`
+	// Offset is the byte offset in the file right after the last processed record.
+	Offset int64 `json:"offset"`
+	// OutputOffset is the size of the output file right after the last processed record.
+	OutputOffset int64 `json:"outputOffset"`
+	// QuarantineOffset is the size of the quarantine file right after the last quarantined record.
+	QuarantineOffset int64 `json:"quarantineOffset"`
+	// BudgetExceeded tells that the task was already paused for exceeding its error budget.
+	BudgetExceeded bool `json:"budgetExceeded"`
+	// RangeStart is the byte offset of the first record of the range of the task, if it has one.
+	RangeStart int64 `json:"rangeStart,omitempty"`
+	// Breakpoints are the current breakpoints of the task, which may differ from the configured ones.
+	Breakpoints []Breakpoint `json:"breakpoints,omitempty"`
+	// BreakRow is the row the task last paused at for a breakpoint, which doesn't fire there again.
+	BreakRow int64 `json:"breakRow,omitempty"`
+
+	Processed   int64         `json:"processed"`
+	Failed      int64         `json:"failed"`
+	Skipped     int64         `json:"skipped"`
+	Quarantined int64         `json:"quarantined"`
+	Retries     int64         `json:"retries"`
+	Total       int64         `json:"total"`
+	TotalExact  bool          `json:"totalExact"`
+	ActiveTime  time.Duration `json:"activeTime"`
+	Deadline    time.Time     `json:"deadline"`
+	Events      []Event       `json:"events"`
+	RowErrors   []Error       `json:"rowErrors,omitempty"`
+}
+
+// Restore rebuilds a task from the checkpoint file at the given path, see RestoreCheckpoint.
+func Restore(path string, s *Scheduler) (*Task, error) {
+	cp, err := ReadCheckpoint(path)
+	if err != nil {
+		return nil, err
+	}
+	return RestoreCheckpoint(cp, s)
+}
+
+// ReadCheckpoint reads the checkpoint file at the given path.
+func ReadCheckpoint(path string) (Checkpoint, error) {
+	var cp Checkpoint
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return cp, err
+	}
+
+	err = json.Unmarshal(b, &cp)
+	return cp, err
+}
+
+// RestoreCheckpoint rebuilds a task from its checkpoint. Running tasks are
+// queued in the scheduler to continue from the checkpointed record, and
+// paused tasks wait to be resumed.
+func RestoreCheckpoint(cp Checkpoint, s *Scheduler) (*Task, error) {
+	t, err := NewTask(cp.ID, cp.FilePath, cp.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	t.State = cp.State
+	t.sched = s
+	t.record = cp.Record
+	t.offset = cp.Offset
+	t.outputOffset = cp.OutputOffset
+	t.quarantineOffset = cp.QuarantineOffset
+	t.budgetExceeded = cp.BudgetExceeded
+	t.rangeStart = cp.RangeStart
+	if cp.Breakpoints != nil {
+		t.breakpoints = cp.Breakpoints
+	}
+	t.breakRow = cp.BreakRow
+	t.processed, t.failed, t.skipped, t.quarantined = cp.Processed, cp.Failed, cp.Skipped, cp.Quarantined
+	t.retries = cp.Retries
+	t.total, t.totalExact = cp.Total, cp.TotalExact
+	t.activeTime = cp.ActiveTime
+	t.deadline = cp.Deadline
+	if len(cp.Events) > 0 {
+		t.events = cp.Events
+	}
+	if cp.Error != nil {
+		t.Err = cp.Error
+	}
+	t.rowErrors = cp.RowErrors
+	t.metadata = cp.Metadata
+	if t.metadata.Filename == "" {
+		// Older checkpoints only have the name in the path of the uploaded file.
+		t.metadata.Filename = strings.TrimPrefix(filepath.Base(cp.FilePath), cp.ID)
+	}
+	t.saved = &cp
+
+	// Changes which were still to be applied by the worker are considered
+	// done, and running tasks need a slot in the scheduler again.
+	restored := map[Status]Status{
+		TaskPausing:     TaskPaused,
+		TaskTerminating: TaskTerminated,
+		TaskRunning:     TaskQueued,
+	}
+	if to, ok := restored[t.State]; ok {
+		t.addEvent(t.State, to, systemCause("restored after restart"))
+		t.State = to
+	}
+	log.Printf("[%s] restored as %s at record %d\n", t.ID, t.State, t.record)
+
+	switch {
+	case t.State.final():
+		close(t.done)
+	case t.State == TaskQueued:
+		s.enqueue(t)
+	case t.State == TaskPaused:
+		t.started = true
+		t.restoredPaused = true
+		go t.process()
+	}
+
+	return t, nil
+}
+
+// Record returns the state of the task to persist: its position in the file
+// as of the last checkpoint, along with its current status and history.
+func (t *Task) Record() Checkpoint {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	cp := t.newCheckpoint()
+	if t.saved != nil {
+		saved := *t.saved
+		saved.Config, saved.Metadata, saved.State, saved.Error = cp.Config, cp.Metadata, cp.State, cp.Error
+		saved.Deadline, saved.Events, saved.Breakpoints = cp.Deadline, cp.Events, cp.Breakpoints
+		cp = saved
+	}
+	return cp
+}
+
+// checkpoint persists the current state of the task. Failures are only logged
+// as losing a checkpoint shouldn't fail the task itself.
+func (t *Task) checkpoint() {
+	t.saving.Lock()
+	defer t.saving.Unlock()
+
+	t.mutex.Lock()
+	cp := t.newCheckpoint()
+	t.mutex.Unlock()
+
+	if err := writeJSON(t.FilePath+CheckpointExt, cp); err != nil {
+		log.Printf("[%s] saving checkpoint: %v\n", t.ID, err)
+		return
+	}
+	t.lastCheckpoint = time.Now()
+
+	t.mutex.Lock()
+	t.saved = &cp
+	onChange := t.onChange
+	t.mutex.Unlock()
+
+	if onChange != nil {
+		onChange(t)
+	}
+}
+
+// saveRecord rewrites the checkpoint file of the task, if it has one, with its
+// record, so that changes made while its worker doesn't take checkpoints
+// survive restarts without a store. Failures are only logged.
+func (t *Task) saveRecord() {
+	t.saving.Lock()
+	defer t.saving.Unlock()
+
+	t.mutex.Lock()
+	saved := t.saved != nil
+	t.mutex.Unlock()
+	if !saved {
+		return
+	}
+
+	if err := writeJSON(t.FilePath+CheckpointExt, t.Record()); err != nil {
+		log.Printf("[%s] saving checkpoint: %v\n", t.ID, err)
+	}
+}
+
+// newCheckpoint returns the current state of the task. The caller must hold the mutex.
+func (t *Task) newCheckpoint() Checkpoint {
+	cp := Checkpoint{
+		ID:           t.ID,
+		FilePath:     t.FilePath,
+		Config:       t.Config,
+		Metadata:     t.metadata.clone(),
+		State:        t.State,
+		Record:       t.record,
+		Offset:       t.offset,
+		OutputOffset: t.outputOffset,
+
+		QuarantineOffset: t.quarantineOffset,
+		BudgetExceeded:   t.budgetExceeded,
+		RangeStart:       t.rangeStart,
+		Breakpoints:      append([]Breakpoint(nil), t.breakpoints...),
+		BreakRow:         t.breakRow,
+
+		Processed:   t.processed,
+		Failed:      t.failed,
+		Skipped:     t.skipped,
+		Quarantined: t.quarantined,
+		Retries:     t.retries,
+		Total:       t.total,
+		TotalExact:  t.totalExact,
+		ActiveTime:  t.activeTime,
+		Deadline:    t.deadline,
+		Events:      append([]Event(nil), t.events...),
+		RowErrors:   append([]Error(nil), t.rowErrors...),
+	}
+	if t.Err != nil {
+		cp.Error = taskError(t.Err)
+	}
+	if !t.activeSince.IsZero() {
+		cp.ActiveTime += time.Since(t.activeSince)
+	}
+	return cp
+}
+
+// checkpointIfDue persists the state if the checkpoint interval has passed since the last one.
+// The output is flushed first so that the checkpoint covers it.
+func (t *Task) checkpointIfDue() error {
+	if t.Config.CheckpointInterval <= 0 || time.Since(t.lastCheckpoint) < t.Config.CheckpointInterval {
+		return nil
+	}
+	if err := t.flushOutput(); err != nil {
+		return err
+	}
+	t.checkpoint()
+	return nil
+}
+
+// writeJSON atomically replaces the file with the JSON encoding of v.
+func writeJSON(path string, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// offsetReader counts the bytes read from the underlying reader, starting at offset n.
+type offsetReader struct {
+	r io.Reader
+	n int64
+}
+
+func (o *offsetReader) Read(p []byte) (int, error) {
+	n, err := o.r.Read(p)
+	o.n += int64(n)
+	return n, err
+}
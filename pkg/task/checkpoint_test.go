@@ -0,0 +1,38 @@
+package task
+
+import (
+	"bufio"
+	"encoding/csv"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestOffsetReader(t *testing.T) {
+	lines := []string{
+		"id,name\n",
+		"1,x\n",
+		"2,\"multi\nline\"\n",
+		"3,z\n",
+	}
+
+	counter := &offsetReader{r: strings.NewReader(strings.Join(lines, ""))}
+	buf := bufio.NewReader(counter)
+	csvR := csv.NewReader(buf)
+
+	var expected int64
+	for _, l := range lines {
+		if _, err := csvR.Read(); err != nil {
+			t.Fatal(err)
+		}
+
+		expected += int64(len(l))
+		if offset := counter.n - int64(buf.Buffered()); offset != expected {
+			t.Fatalf("incorrect offset. expected: %d; got: %d", expected, offset)
+		}
+	}
+
+	if _, err := csvR.Read(); err != io.EOF {
+		t.Fatalf("expected EOF, got: %v", err)
+	}
+}
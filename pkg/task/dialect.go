@@ -0,0 +1,120 @@
+package task
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf8"
+)
+
+// ErrInvalidDialect is returned when the CSV dialect of a task can't be used.
+var ErrInvalidDialect = errors.New("invalid dialect")
+
+// Dialect describes how the uploaded CSV file is formatted.
+// The zero value is the format read by encoding/csv by default.
+type Dialect struct {
+	// Delimiter is the field delimiter, a comma if zero.
+	Delimiter rune `json:"delimiter,omitempty"`
+	// Comment, if not zero, is the character starting comment lines.
+	Comment rune `json:"comment,omitempty"`
+	// LazyQuotes allows quotes in unquoted fields and unescaped quotes in quoted fields.
+	LazyQuotes bool `json:"lazyQuotes,omitempty"`
+	// TrimLeadingSpace ignores the leading white space of fields.
+	TrimLeadingSpace bool `json:"trimLeadingSpace,omitempty"`
+	// FieldsPerRecord is the number of fields every record must have. If zero,
+	// it is the number of fields of the first record, if negative it may vary.
+	FieldsPerRecord int `json:"fieldsPerRecord,omitempty"`
+	// Header tells that the first record holds the names of the columns.
+	Header bool `json:"header,omitempty"`
+}
+
+// validate returns an error wrapping ErrInvalidDialect if the dialect can't be used by a csv.Reader.
+func (d Dialect) validate() error {
+	if d.Delimiter != 0 && !validDelim(d.Delimiter) {
+		return fmt.Errorf("%w: delimiter %q is not allowed", ErrInvalidDialect, d.Delimiter)
+	}
+	if d.Comment != 0 && !validDelim(d.Comment) {
+		return fmt.Errorf("%w: comment %q is not allowed", ErrInvalidDialect, d.Comment)
+	}
+	if d.Comment != 0 && d.Comment == d.Delimiter {
+		return fmt.Errorf("%w: comment and delimiter must differ", ErrInvalidDialect)
+	}
+	return nil
+}
+
+// validDelim mirrors the checks done by csv.Reader on delimiters and comments.
+func validDelim(r rune) bool {
+	return r != '"' && r != '\r' && r != '\n' && utf8.ValidRune(r) && r != utf8.RuneError
+}
+
+// reader returns a csv.Reader of the dialect reading from r.
+func (d Dialect) reader(r io.Reader) *csv.Reader {
+	csvR := csv.NewReader(r)
+	if d.Delimiter != 0 {
+		csvR.Comma = d.Delimiter
+	}
+	csvR.Comment = d.Comment
+	csvR.LazyQuotes = d.LazyQuotes
+	csvR.TrimLeadingSpace = d.TrimLeadingSpace
+	csvR.FieldsPerRecord = d.FieldsPerRecord
+	return csvR
+}
+
+// recordReader reads the records of a file under a dialect, keeping track
+// of the offset right after each of them.
+type recordReader struct {
+	csv     *csv.Reader
+	counter *offsetReader
+	buf     *bufio.Reader
+}
+
+// records returns a recordReader of the dialect reading the file from the
+// offset. Records must have as many fields as the header, if not nil, unless
+// the dialect tells otherwise.
+func (d Dialect) records(file io.ReadSeeker, offset int64, header Header) (*recordReader, error) {
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	// csv.Reader reuses a *bufio.Reader instead of wrapping it again, so the
+	// offset of the last record is the bytes read minus the buffered ones.
+	counter := &offsetReader{r: file, n: offset}
+	buf := bufio.NewReader(counter)
+	csvR := d.reader(buf)
+	if csvR.FieldsPerRecord == 0 && header != nil {
+		csvR.FieldsPerRecord = len(header)
+	}
+	return &recordReader{csv: csvR, counter: counter, buf: buf}, nil
+}
+
+// read returns the next record along with the offset right after it, which
+// is also returned when the record couldn't be parsed.
+func (r *recordReader) read() ([]string, int64, error) {
+	record, err := r.csv.Read()
+	return record, r.counter.n - int64(r.buf.Buffered()), err
+}
+
+// Header holds the names of the columns of a file, in order.
+type Header []string
+
+// Index returns the index of the named column, or -1 if there is no such column.
+func (h Header) Index(name string) int {
+	for i, n := range h {
+		if n == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Field returns the value of the named column in the record.
+// It reports false if there is no such column or the record is too short.
+func (h Header) Field(record []string, name string) (string, bool) {
+	i := h.Index(name)
+	if i < 0 || i >= len(record) {
+		return "", false
+	}
+	return record[i], true
+}
@@ -0,0 +1,87 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// namesProcessor outputs the name column of every record, addressed by the header.
+type namesProcessor struct {
+	mu     sync.Mutex
+	header Header
+}
+
+func (p *namesProcessor) Init(context.Context, string) error { return nil }
+
+func (p *namesProcessor) Header(_ context.Context, header Header) ([]string, error) {
+	p.mu.Lock()
+	p.header = header
+	p.mu.Unlock()
+	return []string{"name"}, nil
+}
+
+func (p *namesProcessor) Process(_ context.Context, record []string) ([]string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	name, ok := p.header.Field(record, "name")
+	if !ok {
+		return nil, errors.New("no name")
+	}
+	return []string{name}, nil
+}
+
+func (p *namesProcessor) Flush() error { return nil }
+
+func (p *namesProcessor) Close() error { return nil }
+
+func TestDialectHeader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dialect.csv")
+	data := "# exported from billing\nid; name\n1; x\n# a comment\n2; \"y;z\"\n"
+	if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tk, err := NewTask("dialect", path, Config{Dialect: Dialect{
+		Delimiter:        ';',
+		Comment:          '#',
+		TrimLeadingSpace: true,
+		Header:           true,
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tk.processor = &namesProcessor{}
+
+	tk.Run(Cause{})
+	waitStatus(tk, TaskFinished, t)
+
+	if p := tk.Progress(); p.Processed != 2 || p.Total != 2 {
+		t.Fatalf("incorrect progress: %+v", p)
+	}
+
+	b, err := ioutil.ReadFile(tk.OutputPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "name\nx\ny;z\n"; string(b) != expected {
+		t.Fatalf("incorrect output. expected: %q; got: %q", expected, b)
+	}
+}
+
+func TestDialectInvalid(t *testing.T) {
+	for _, d := range []Dialect{
+		{Delimiter: '"'},
+		{Delimiter: '\n'},
+		{Comment: '\r'},
+		{Delimiter: ';', Comment: ';'},
+	} {
+		if _, err := NewTask("invalid", "invalid.csv", Config{Dialect: d}); !errors.Is(err, ErrInvalidDialect) {
+			t.Fatalf("expected invalid dialect for %+v, got: %v", d, err)
+		}
+	}
+}
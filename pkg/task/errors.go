@@ -0,0 +1,121 @@
+package task
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+)
+
+// maxRowErrors is the number of most recent errors of skipped or quarantined records kept by a task.
+const maxRowErrors = 10
+
+// Error is an error of a task, along with the record which caused it if any.
+type Error struct {
+	Message string `json:"message"`
+	// Row is the number of the record in the file, starting at 1 and not counting the header.
+	Row int64 `json:"row,omitempty"`
+	// Offset is the byte offset of the record in the file.
+	Offset int64 `json:"offset,omitempty"`
+	// Column is the column at which the record couldn't be parsed, if known.
+	Column int `json:"column,omitempty"`
+	// Line is the raw content of the record, without the line ending.
+	Line string `json:"line,omitempty"`
+	// Cause is the message of the underlying error.
+	Cause string `json:"cause,omitempty"`
+
+	err error
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the underlying error, which isn't kept across restarts.
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// UnmarshalJSON also accepts a plain message, as saved by older checkpoints.
+func (e *Error) UnmarshalJSON(b []byte) error {
+	var message string
+	if err := json.Unmarshal(b, &message); err == nil {
+		*e = Error{Message: message}
+		return nil
+	}
+
+	type plain Error
+	return json.Unmarshal(b, (*plain)(e))
+}
+
+// taskError returns err as an *Error, wrapping it if needed.
+func taskError(err error) *Error {
+	var terr *Error
+	if errors.As(err, &terr) {
+		return terr
+	}
+	return &Error{Message: err.Error(), Cause: err.Error(), err: err}
+}
+
+// recordError returns an error of the record between the start and end
+// offsets of the file, the column being zero if unknown.
+func (t *Task) recordError(file *os.File, start, end int64, column int, cause error) *Error {
+	e, err := rowError(file, t.row()+1, start, end, column, cause)
+	if err != nil {
+		log.Printf("[%s] reading row %d: %v\n", t.ID, e.Row, err)
+	}
+	return e
+}
+
+// rowError returns an error of the record at the row, between the start and
+// end offsets of the file. The error is still returned without its raw line
+// if the line couldn't be read.
+func rowError(file *os.File, row, start, end int64, column int, cause error) (*Error, error) {
+	e := &Error{
+		Row:    row,
+		Offset: start,
+		Column: column,
+		Cause:  cause.Error(),
+		err:    cause,
+	}
+
+	e.Message = fmt.Sprintf("row %d: %v", e.Row, cause)
+	if column > 0 {
+		e.Message = fmt.Sprintf("row %d, column %d: %v", e.Row, column, cause)
+	}
+
+	line, err := readLine(file, start, end)
+	e.Line = line
+	return e, err
+}
+
+// Failure returns the error the task stopped with, or nil if it didn't get one.
+func (t *Task) Failure() *Error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.Err == nil {
+		return nil
+	}
+	return taskError(t.Err)
+}
+
+// RowErrors returns the errors of the most recently skipped or quarantined records, oldest first.
+func (t *Task) RowErrors() []Error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	errs := make([]Error, len(t.rowErrors))
+	copy(errs, t.rowErrors)
+	return errs
+}
+
+// addRowError keeps the error of a skipped or quarantined record, dropping
+// the oldest one if there are too many. The caller must hold the mutex.
+func (t *Task) addRowError(e *Error) {
+	if len(t.rowErrors) == maxRowErrors {
+		t.rowErrors = append(t.rowErrors[:0], t.rowErrors[1:]...)
+	}
+	t.rowErrors = append(t.rowErrors, *e)
+}
@@ -0,0 +1,63 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+var errFailing = errors.New("downstream unavailable")
+
+// failingProcessor fails every record.
+type failingProcessor struct{}
+
+func (failingProcessor) Init(context.Context, string) error { return nil }
+
+func (failingProcessor) Process(context.Context, []string) ([]string, error) { return nil, errFailing }
+
+func (failingProcessor) Flush() error { return nil }
+
+func (failingProcessor) Close() error { return nil }
+
+func TestRowErrorsKept(t *testing.T) {
+	tk := &Task{}
+
+	tk.mutex.Lock()
+	for i := int64(1); i <= maxRowErrors+5; i++ {
+		tk.addRowError(&Error{Row: i})
+	}
+	tk.mutex.Unlock()
+
+	errs := tk.RowErrors()
+	if len(errs) != maxRowErrors || errs[0].Row != 6 || errs[maxRowErrors-1].Row != maxRowErrors+5 {
+		t.Fatalf("incorrect row errors kept: %+v", errs)
+	}
+}
+
+func TestProcessError(t *testing.T) {
+	tk := newTestTask("process-error", t)
+	tk.processor = failingProcessor{}
+
+	tk.Run(Cause{})
+	waitStatus(tk, TaskGotError, t)
+
+	e := tk.Failure()
+	if e.Row != 1 || e.Line != "id,name" || e.Message != "row 1: "+errFailing.Error() || !errors.Is(tk.Err, errFailing) {
+		t.Fatalf("incorrect error: %+v", e)
+	}
+	if p := tk.Progress(); p.Failed != 1 {
+		t.Fatalf("incorrect progress: %+v", p)
+	}
+}
+
+func TestErrorUnmarshal(t *testing.T) {
+	var e Error
+	if err := json.Unmarshal([]byte(`"something broke"`), &e); err != nil || e.Message != "something broke" {
+		t.Fatalf("incorrect error from plain message: %+v, %v", e, err)
+	}
+
+	if err := json.Unmarshal([]byte(`{"message":"row 2: bad","row":2,"line":"2,b"}`), &e); err != nil || e.Row != 2 || e.Line != "2,b" {
+		t.Fatalf("incorrect error: %+v, %v", e, err)
+	}
+}
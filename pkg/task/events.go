@@ -0,0 +1,92 @@
+package task
+
+import "time"
+
+// SystemActor is the actor of status changes made by the task itself or the scheduler.
+const SystemActor = "system"
+
+// Cause tells who asked for a change of status and why, both are optional.
+type Cause struct {
+	Actor  string `json:"actor,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// systemCause returns the cause of a change made by the task itself or the scheduler.
+func systemCause(reason string) Cause {
+	return Cause{Actor: SystemActor, Reason: reason}
+}
+
+// Event is a change of status of a task.
+type Event struct {
+	From Status    `json:"from"`
+	To   Status    `json:"to"`
+	Time time.Time `json:"time"`
+	Cause
+}
+
+// Timeline summarizes the events of a task.
+type Timeline struct {
+	CreatedAt  time.Time  `json:"createdAt"`
+	StartedAt  *time.Time `json:"startedAt,omitempty"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+	// Active is the number of seconds spent processing records.
+	Active float64 `json:"active"`
+	// Paused is the number of seconds spent paused.
+	Paused float64 `json:"paused"`
+}
+
+// Events returns the history of status changes of the task, oldest first.
+func (t *Task) Events() []Event {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	events := make([]Event, len(t.events))
+	copy(events, t.events)
+	return events
+}
+
+// Timeline returns the timeline of the task derived from its events.
+func (t *Task) Timeline() Timeline {
+	return timeline(t.Events(), time.Now())
+}
+
+func timeline(events []Event, now time.Time) Timeline {
+	var tl Timeline
+	var active, paused time.Duration
+
+	for i, e := range events {
+		if i == 0 {
+			tl.CreatedAt = e.Time
+		}
+
+		if e.To == TaskRunning && tl.StartedAt == nil {
+			started := e.Time
+			tl.StartedAt = &started
+		}
+		if e.To.final() && tl.FinishedAt == nil {
+			finished := e.Time
+			tl.FinishedAt = &finished
+		}
+
+		// Time in this status lasts until the next event, or until now.
+		until := now
+		if i+1 < len(events) {
+			until = events[i+1].Time
+		}
+		switch e.To {
+		case TaskRunning, TaskPausing, TaskTerminating:
+			active += until.Sub(e.Time)
+		case TaskPaused:
+			paused += until.Sub(e.Time)
+		}
+	}
+
+	tl.Active = active.Seconds()
+	tl.Paused = paused.Seconds()
+	return tl
+}
+
+// addEvent appends the change of status to the history. The caller must hold the mutex.
+func (t *Task) addEvent(from, to Status, cause Cause) {
+	t.events = append(t.events, Event{From: from, To: to, Time: time.Now(), Cause: cause})
+}
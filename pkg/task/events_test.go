@@ -0,0 +1,58 @@
+package task
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeline(t *testing.T) {
+	start := time.Date(2020, 9, 1, 12, 0, 0, 0, time.UTC)
+	at := func(sec int) time.Time { return start.Add(time.Duration(sec) * time.Second) }
+
+	events := []Event{
+		{To: TaskNotStarted, Time: at(0)},
+		{From: TaskNotStarted, To: TaskQueued, Time: at(1)},
+		{From: TaskQueued, To: TaskRunning, Time: at(3)},
+		{From: TaskRunning, To: TaskPausing, Time: at(5)},
+		{From: TaskPausing, To: TaskPaused, Time: at(6)},
+		{From: TaskPaused, To: TaskRunning, Time: at(10)},
+		{From: TaskRunning, To: TaskFinished, Time: at(12)},
+	}
+
+	tl := timeline(events, at(100))
+
+	if !tl.CreatedAt.Equal(at(0)) || !tl.StartedAt.Equal(at(3)) || !tl.FinishedAt.Equal(at(12)) {
+		t.Fatalf("incorrect timestamps: %+v", tl)
+	}
+	if tl.Active != 5 || tl.Paused != 4 {
+		t.Fatalf("incorrect durations. expected active: 5, paused: 4; got: %+v", tl)
+	}
+}
+
+func TestEventsRecordCause(t *testing.T) {
+	tk := newTestTask("events", t)
+
+	if err := tk.Run(Cause{Actor: "alice"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tk.Terminate(Cause{Actor: "bob", Reason: "maintenance"}); err != nil {
+		t.Fatal(err)
+	}
+	waitStatus(tk, TaskTerminated, t)
+
+	events := tk.Events()
+	expected := []Event{
+		{To: TaskNotStarted, Cause: systemCause("created")},
+		{From: TaskNotStarted, To: TaskRunning, Cause: Cause{Actor: "alice"}},
+		{From: TaskRunning, To: TaskTerminating, Cause: Cause{Actor: "bob", Reason: "maintenance"}},
+		{From: TaskTerminating, To: TaskTerminated, Cause: systemCause("terminated")},
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("incorrect number of events. expected: %d; got: %+v", len(expected), events)
+	}
+	for i, e := range expected {
+		if events[i].From != e.From || events[i].To != e.To || events[i].Cause != e.Cause {
+			t.Fatalf("incorrect event %d. expected: %+v; got: %+v", i, e, events[i])
+		}
+	}
+}
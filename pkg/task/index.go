@@ -0,0 +1,77 @@
+package task
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+)
+
+// indexInterval is the number of rows between two entries of an offset index.
+const indexInterval = 1000
+
+// IndexExt is the extension of offset index files, which are kept next to the uploaded file.
+const IndexExt = ".index"
+
+// offsetIndex holds the offsets of the rows 1, 1+indexInterval, 1+2*indexInterval
+// and so on of a file, so that reading can start close to any row without
+// parsing all the records before it. It may only cover the beginning of the file.
+type offsetIndex struct {
+	// Size and Dialect are the ones of the file the index was built for.
+	Size    int64   `json:"size"`
+	Dialect Dialect `json:"dialect"`
+	Offsets []int64 `json:"offsets"`
+}
+
+// nearest returns the last indexed row at or before the row, along with its offset.
+func (idx *offsetIndex) nearest(row int64) (int64, int64) {
+	i := (row - 1) / indexInterval
+	if n := int64(len(idx.Offsets)); i >= n {
+		i = n - 1
+	}
+	return i*indexInterval + 1, idx.Offsets[i]
+}
+
+// add indexes the offset of the row if it is the next one to be indexed, and
+// reports whether it was.
+func (idx *offsetIndex) add(row, offset int64) bool {
+	if row != int64(len(idx.Offsets))*indexInterval+1 {
+		return false
+	}
+	idx.Offsets = append(idx.Offsets, offset)
+	return true
+}
+
+// IndexPath returns the path of the offset index of the file of the task.
+func (t *Task) IndexPath() string {
+	return t.FilePath + IndexExt
+}
+
+// loadIndex returns the offset index of the file of the task, given its size
+// and the offset of its first row. If there is none, or it was built for
+// another file or dialect, a new index only holding the first row is returned.
+func (t *Task) loadIndex(size, first int64) *offsetIndex {
+	idx := &offsetIndex{Size: size, Dialect: t.Config.Dialect, Offsets: []int64{first}}
+
+	b, err := ioutil.ReadFile(t.IndexPath())
+	if err != nil {
+		return idx
+	}
+
+	var saved offsetIndex
+	if err := json.Unmarshal(b, &saved); err != nil {
+		log.Printf("[%s] reading offset index: %v\n", t.ID, err)
+		return idx
+	}
+	if saved.Size != size || saved.Dialect != t.Config.Dialect || len(saved.Offsets) == 0 || saved.Offsets[0] != first {
+		return idx
+	}
+	return &saved
+}
+
+// saveIndex persists the offset index of the file of the task. Failures are
+// only logged as the index can be built again.
+func (t *Task) saveIndex(idx *offsetIndex) {
+	if err := writeJSON(t.IndexPath(), idx); err != nil {
+		log.Printf("[%s] saving offset index: %v\n", t.ID, err)
+	}
+}
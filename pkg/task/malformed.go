@@ -0,0 +1,106 @@
+package task
+
+import (
+	"encoding/csv"
+	"errors"
+	"log"
+	"os"
+	"strings"
+)
+
+// MalformedPolicy tells what a task does with records which can't be parsed.
+type MalformedPolicy string
+
+// Various possible malformed row policies.
+const (
+	// MalformedFail stops the task with the got-error status.
+	MalformedFail MalformedPolicy = "fail"
+	// MalformedSkip skips the record, counting it as skipped.
+	MalformedSkip MalformedPolicy = "skip"
+	// MalformedQuarantine writes the record to the quarantine file of the task,
+	// records failing processing are quarantined as well instead of failing the task.
+	MalformedQuarantine MalformedPolicy = "quarantine"
+)
+
+// ErrUnknownPolicy is returned when the malformed row policy isn't supported.
+var ErrUnknownPolicy = errors.New("unknown malformed row policy")
+
+// ErrInvalidBudget is returned when the error budget isn't a percentage.
+var ErrInvalidBudget = errors.New("error budget must be between 0 and 100")
+
+// malformed handles a record between the start and end offsets of the file
+// which couldn't be parsed, according to the malformed row policy.
+func (t *Task) malformed(file *os.File, start, end int64, perr *csv.ParseError) error {
+	err := t.recordError(file, start, end, parseErrorColumn(perr), perr.Err)
+
+	switch t.Config.Malformed {
+	case MalformedSkip:
+	case MalformedQuarantine:
+		if werr := t.quarantineRecord(err); werr != nil {
+			return werr
+		}
+	default:
+		return err
+	}
+
+	t.mutex.Lock()
+	t.record++
+	t.offset = end
+	if t.Config.Malformed == MalformedQuarantine {
+		t.quarantined++
+	} else {
+		t.skipped++
+	}
+	t.addRowError(err)
+	t.mutex.Unlock()
+
+	log.Printf("[%s] %s malformed %v\n", t.ID, t.Config.Malformed, err)
+	t.checkErrorBudget()
+	return nil
+}
+
+// parseErrorColumn returns the column at which the record couldn't be
+// parsed, zero if the error is about the whole record.
+func parseErrorColumn(perr *csv.ParseError) int {
+	if perr.Err == csv.ErrFieldCount {
+		return 0
+	}
+	return perr.Column
+}
+
+// readLine returns the raw content of the file between the offsets, without the line ending.
+func readLine(file *os.File, start, end int64) (string, error) {
+	b := make([]byte, end-start)
+	if _, err := file.ReadAt(b, start); err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(b), "\r\n"), nil
+}
+
+// checkErrorBudget pauses the task the first time the share of malformed
+// records in the file exceeds the error budget.
+func (t *Task) checkErrorBudget() {
+	if t.Config.ErrorBudget <= 0 {
+		return
+	}
+
+	t.mutex.Lock()
+	exceeded := t.budgetExceeded
+	t.mutex.Unlock()
+	if exceeded {
+		return
+	}
+
+	p := t.Progress()
+	if p.Total == 0 || float64(p.Skipped+p.Quarantined)/float64(p.Total)*100 <= t.Config.ErrorBudget {
+		return
+	}
+
+	t.mutex.Lock()
+	t.budgetExceeded = true
+	t.mutex.Unlock()
+
+	if t.transition(TaskPausing, systemCause("error budget exceeded"), TaskRunning) {
+		log.Printf("[%s] pausing, error budget of %v%% exceeded\n", t.ID, t.Config.ErrorBudget)
+	}
+}
@@ -0,0 +1,107 @@
+package task
+
+import (
+	"encoding/csv"
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+const malformedCSV = "1,a\n2,b\"\n3,c\"\n4,d\n"
+
+func TestMalformedFail(t *testing.T) {
+	tk := newTestTask("fail", t, withData(malformedCSV))
+
+	tk.Run(Cause{})
+	waitStatus(tk, TaskGotError, t)
+
+	if tk.Err == nil || !strings.HasPrefix(tk.Err.Error(), "row 2, column ") || !errors.Is(tk.Err, csv.ErrBareQuote) {
+		t.Fatalf("incorrect error: %v", tk.Err)
+	}
+
+	e := tk.Failure()
+	if e.Row != 2 || e.Offset != 4 || e.Column == 0 || e.Line != `2,b"` || e.Cause != csv.ErrBareQuote.Error() {
+		t.Fatalf("incorrect error details: %+v", e)
+	}
+	if p := tk.Progress(); p.Processed != 1 {
+		t.Fatalf("incorrect progress: %+v", p)
+	}
+}
+
+func TestMalformedSkip(t *testing.T) {
+	tk := newTestTask("skip", t, withData(malformedCSV), withConfig(Config{Malformed: MalformedSkip}))
+
+	tk.Run(Cause{})
+	waitStatus(tk, TaskFinished, t)
+
+	if p := tk.Progress(); p.Processed != 2 || p.Skipped != 2 || p.Total != 4 {
+		t.Fatalf("incorrect progress: %+v", p)
+	}
+	if errs := tk.RowErrors(); len(errs) != 2 || errs[0].Row != 2 || errs[1].Row != 3 {
+		t.Fatalf("incorrect row errors: %+v", errs)
+	}
+
+	b, err := ioutil.ReadFile(tk.OutputPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "1,a\n4,d\n" {
+		t.Fatalf("incorrect output: %q", b)
+	}
+}
+
+func TestMalformedQuarantine(t *testing.T) {
+	tk := newTestTask("quarantine", t, withData(malformedCSV), withConfig(Config{Malformed: MalformedQuarantine}))
+
+	tk.Run(Cause{})
+	waitStatus(tk, TaskFinished, t)
+
+	if p := tk.Progress(); p.Processed != 2 || p.Quarantined != 2 {
+		t.Fatalf("incorrect progress: %+v", p)
+	}
+
+	f, err := os.Open(tk.QuarantinePath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 3 || records[1][0] != "2" || records[1][2] != `2,b"` || records[2][0] != "3" {
+		t.Fatalf("incorrect quarantine file: %q", records)
+	}
+}
+
+func TestErrorBudget(t *testing.T) {
+	tk := newTestTask("budget", t, withData(malformedCSV), withConfig(Config{Malformed: MalformedSkip, ErrorBudget: 25}))
+
+	tk.Run(Cause{})
+	waitStatus(tk, TaskPaused, t)
+
+	if p := tk.Progress(); p.Skipped != 2 {
+		t.Fatalf("expected the task to pause on the second malformed record: %+v", p)
+	}
+
+	events := tk.Events()
+	if pausing := events[len(events)-2]; pausing.To != TaskPausing || pausing.Reason != "error budget exceeded" {
+		t.Fatalf("incorrect pause event: %+v", pausing)
+	}
+
+	// The budget only pauses the task once.
+	tk.Resume(Cause{})
+	waitStatus(tk, TaskFinished, t)
+}
+
+func TestInvalidPolicy(t *testing.T) {
+	if _, err := NewTask("policy", "policy.csv", Config{Malformed: "ignore"}); err != ErrUnknownPolicy {
+		t.Fatalf("expected unknown policy, got: %v", err)
+	}
+	if _, err := NewTask("budget", "budget.csv", Config{ErrorBudget: 120}); err != ErrInvalidBudget {
+		t.Fatalf("expected invalid budget, got: %v", err)
+	}
+}
@@ -0,0 +1,129 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// ErrInvalidLabel is returned when the key or value of a label has unsupported characters.
+var ErrInvalidLabel = errors.New("invalid label")
+
+var (
+	labelKey   = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._/-]{0,62}$`)
+	labelValue = regexp.MustCompile(`^[A-Za-z0-9._/-]{0,63}$`)
+)
+
+// Metadata describes the uploaded file of a task and how the task is labelled.
+type Metadata struct {
+	// Filename is the original name of the uploaded file.
+	Filename string `json:"filename"`
+	// Size is the size of the uploaded file in bytes.
+	Size int64 `json:"size"`
+	// SHA256 is the hex encoded SHA-256 checksum of the uploaded file.
+	SHA256 string `json:"sha256,omitempty"`
+	// ContentType is the media type the file was uploaded with.
+	ContentType string `json:"contentType,omitempty"`
+	// Uploader is the actor who uploaded the file.
+	Uploader string `json:"uploader,omitempty"`
+	// Labels are key/value pairs to find tasks by, e.g. team=billing.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Parent is the ID of the task this one re-runs or replays, if any.
+	Parent string `json:"parent,omitempty"`
+
+	// CreatedAt, StartedAt and FinishedAt come from the history of the task,
+	// they are ignored by SetMetadata.
+	CreatedAt  time.Time  `json:"createdAt"`
+	StartedAt  *time.Time `json:"startedAt,omitempty"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+}
+
+// ValidateLabels returns an error wrapping ErrInvalidLabel if a key or value
+// has other characters than letters, digits, '.', '_', '/' and '-', or is
+// longer than 63 characters. Keys can't be empty.
+func ValidateLabels(labels map[string]string) error {
+	for k, v := range labels {
+		if !labelKey.MatchString(k) {
+			return fmt.Errorf("%w: key %q", ErrInvalidLabel, k)
+		}
+		if !labelValue.MatchString(v) {
+			return fmt.Errorf("%w: value %q of %s", ErrInvalidLabel, v, k)
+		}
+	}
+	return nil
+}
+
+// clone returns a copy of the metadata which doesn't share its labels.
+func (m Metadata) clone() Metadata {
+	if m.Labels != nil {
+		labels := make(map[string]string, len(m.Labels))
+		for k, v := range m.Labels {
+			labels[k] = v
+		}
+		m.Labels = labels
+	}
+	return m
+}
+
+// withTimes returns the metadata along with the timestamps of the timeline.
+func (m Metadata) withTimes(tl Timeline) Metadata {
+	m.CreatedAt, m.StartedAt, m.FinishedAt = tl.CreatedAt, tl.StartedAt, tl.FinishedAt
+	return m
+}
+
+// Metadata returns the metadata of the task.
+func (t *Task) Metadata() Metadata {
+	t.mutex.Lock()
+	m := t.metadata.clone()
+	events := append([]Event(nil), t.events...)
+	t.mutex.Unlock()
+
+	return m.withTimes(timeline(events, time.Now()))
+}
+
+// SetMetadata sets the metadata of the task, once its labels are validated.
+func (t *Task) SetMetadata(m Metadata) error {
+	if err := ValidateLabels(m.Labels); err != nil {
+		return err
+	}
+	m.CreatedAt, m.StartedAt, m.FinishedAt = time.Time{}, nil, nil
+
+	t.mutex.Lock()
+	t.metadata = m.clone()
+	t.mutex.Unlock()
+	return nil
+}
+
+// UpdateLabels sets and removes labels of the task, once the new ones are
+// validated. The change is persisted in the checkpoint file, if the task has
+// one, and the OnChange function is called.
+func (t *Task) UpdateLabels(set map[string]string, remove []string) error {
+	if err := ValidateLabels(set); err != nil {
+		return err
+	}
+
+	t.mutex.Lock()
+	labels := make(map[string]string, len(t.metadata.Labels)+len(set))
+	for k, v := range t.metadata.Labels {
+		labels[k] = v
+	}
+	for _, k := range remove {
+		delete(labels, k)
+	}
+	for k, v := range set {
+		labels[k] = v
+	}
+	if len(labels) == 0 {
+		labels = nil
+	}
+	t.metadata.Labels = labels
+	onChange := t.onChange
+	t.mutex.Unlock()
+
+	t.saveRecord()
+	if onChange != nil {
+		onChange(t)
+	}
+	return nil
+}
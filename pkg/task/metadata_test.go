@@ -0,0 +1,66 @@
+package task
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateLabels(t *testing.T) {
+	valid := map[string]string{"team": "billing", "app.kubernetes.io/name": "pipeline", "empty": ""}
+	if err := ValidateLabels(valid); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, labels := range []map[string]string{
+		{"": "x"},
+		{"-team": "x"},
+		{"team": "a,b"},
+		{"team": "a=b"},
+		{"env!": "prod"},
+	} {
+		if err := ValidateLabels(labels); !errors.Is(err, ErrInvalidLabel) {
+			t.Errorf("%v: expected invalid label, got: %v", labels, err)
+		}
+	}
+}
+
+func TestMetadataCopiesLabels(t *testing.T) {
+	tk := newTestTask("metadata", t)
+
+	labels := map[string]string{"team": "billing"}
+	if err := tk.SetMetadata(Metadata{Filename: "jan.csv", Labels: labels}); err != nil {
+		t.Fatal(err)
+	}
+	labels["team"] = "search"
+	tk.Metadata().Labels["team"] = "search"
+
+	if m := tk.Metadata(); m.Filename != "jan.csv" || m.Labels["team"] != "billing" {
+		t.Fatalf("incorrect metadata: %+v", m)
+	}
+}
+
+func TestUpdateLabels(t *testing.T) {
+	tk := newTestTask("labels", t)
+	tk.SetMetadata(Metadata{Labels: map[string]string{"team": "billing", "env": "prod"}})
+
+	changes := 0
+	tk.OnChange(func(*Task) { changes++ })
+
+	if err := tk.UpdateLabels(map[string]string{"team": "search", "tier": "1"}, []string{"env"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tk.UpdateLabels(map[string]string{"bad key": "x"}, nil); !errors.Is(err, ErrInvalidLabel) {
+		t.Fatalf("expected invalid label, got: %v", err)
+	}
+
+	m := tk.Metadata()
+	if len(m.Labels) != 2 || m.Labels["team"] != "search" || m.Labels["tier"] != "1" {
+		t.Fatalf("incorrect labels: %v", m.Labels)
+	}
+	if changes != 1 {
+		t.Fatalf("expected the change to be notified once, got: %d", changes)
+	}
+	if m.CreatedAt.IsZero() || m.StartedAt != nil {
+		t.Fatalf("incorrect timestamps: %+v", m)
+	}
+}
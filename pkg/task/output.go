@@ -0,0 +1,150 @@
+package task
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+)
+
+// Supported formats of the output file.
+const (
+	OutputCSV   = "csv"
+	OutputJSONL = "jsonl"
+)
+
+// DefaultOutput is the format of the output file used when none is specified.
+const DefaultOutput = OutputCSV
+
+// ErrUnknownOutput is returned when the requested output format isn't supported.
+var ErrUnknownOutput = errors.New("unknown output format")
+
+// OutputPath returns the path of the file holding the processed records,
+// which is kept next to the uploaded file.
+func (t *Task) OutputPath() string {
+	return t.FilePath + ".out." + t.Config.Output
+}
+
+// OutputSize returns the size of the output file as of the last time the
+// task flushed it, so that it always ends with a complete record.
+func (t *Task) OutputSize() int64 {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.outputOffset
+}
+
+// output writes the records returned by the processor to the output file.
+// It is only used by the worker of the task.
+type output struct {
+	file    *os.File
+	counter *offsetWriter
+	buf     *bufio.Writer
+	csv     *csv.Writer
+	json    *json.Encoder
+}
+
+// openOutput opens the output file for writing at offset. Anything after it
+// was written after the last checkpoint, and is dropped as those records get
+// processed again.
+func openOutput(path, format string, offset int64) (*output, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if err := file.Truncate(offset); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	o := &output{file: file, counter: &offsetWriter{w: file, n: offset}}
+	o.buf = bufio.NewWriter(o.counter)
+	switch format {
+	case OutputJSONL:
+		o.json = json.NewEncoder(o.buf)
+	default:
+		// csv.Writer reuses the *bufio.Writer instead of wrapping it again.
+		o.csv = csv.NewWriter(o.buf)
+	}
+	return o, nil
+}
+
+func (o *output) write(record []string) error {
+	if o.json != nil {
+		return o.json.Encode(record)
+	}
+	return o.csv.Write(record)
+}
+
+// flush writes the buffered records to the file and returns its size.
+func (o *output) flush() (int64, error) {
+	if o.csv != nil {
+		o.csv.Flush()
+		if err := o.csv.Error(); err != nil {
+			return 0, err
+		}
+	}
+	if err := o.buf.Flush(); err != nil {
+		return 0, err
+	}
+	return o.counter.n, nil
+}
+
+func (o *output) close() (int64, error) {
+	n, err := o.flush()
+	if cerr := o.file.Close(); err == nil {
+		err = cerr
+	}
+	return n, err
+}
+
+// flushOutput writes the buffered output and quarantined records to their
+// files, so that the next checkpoint covers them.
+func (t *Task) flushOutput() error {
+	return t.syncOutput((*output).flush)
+}
+
+// closeOutput flushes and closes the output and quarantine files.
+func (t *Task) closeOutput() error {
+	return t.syncOutput((*output).close)
+}
+
+// syncOutput applies op to the output and quarantine files, and records their sizes.
+func (t *Task) syncOutput(op func(*output) (int64, error)) error {
+	n, err := op(t.out)
+	if err != nil {
+		return err
+	}
+
+	var q int64
+	if t.quarantine != nil {
+		if q, err = op(t.quarantine); err != nil {
+			return err
+		}
+	}
+
+	t.mutex.Lock()
+	t.outputOffset = n
+	if t.quarantine != nil {
+		t.quarantineOffset = q
+	}
+	t.mutex.Unlock()
+	return nil
+}
+
+// offsetWriter counts the bytes written to the underlying writer, starting at offset n.
+type offsetWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (o *offsetWriter) Write(p []byte) (int, error) {
+	n, err := o.w.Write(p)
+	o.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,55 @@
+package task
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestOutputJSONL(t *testing.T) {
+	tk := newTestTask("jsonl", t)
+	tk.Config.Output = OutputJSONL
+
+	tk.Run(Cause{})
+	waitStatus(tk, TaskFinished, t)
+
+	b, err := ioutil.ReadFile(tk.OutputPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "[\"id\",\"name\"]\n[\"1\",\"x\"]\n[\"2\",\"y\"]\n[\"3\",\"z\"]\n"
+	if string(b) != expected {
+		t.Fatalf("incorrect output. expected: %q; got: %q", expected, b)
+	}
+	if tk.OutputSize() != int64(len(expected)) {
+		t.Fatalf("incorrect output size. expected: %d; got: %d", len(expected), tk.OutputSize())
+	}
+}
+
+func TestOpenOutputDropsUncheckpointed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.csv")
+	if err := ioutil.WriteFile(path, []byte("a,b\nc,d\ne,"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	o, err := openOutput(path, OutputCSV, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := o.write([]string{"f", "g"}); err != nil {
+		t.Fatal(err)
+	}
+	n, err := o.close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "a,b\nc,d\nf,g\n" || n != int64(len(b)) {
+		t.Fatalf("incorrect output: %q, size %d", b, n)
+	}
+}
@@ -0,0 +1,289 @@
+package task
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limits of the number of records of a preview.
+const (
+	DefaultPreviewRecords = 10
+	MaxPreviewRecords     = 1000
+)
+
+// ColumnType is the type of the values of a column, as inferred by a preview.
+type ColumnType string
+
+// Various possible column types.
+const (
+	ColumnEmpty     ColumnType = "empty"
+	ColumnInteger   ColumnType = "integer"
+	ColumnNumber    ColumnType = "number"
+	ColumnBoolean   ColumnType = "boolean"
+	ColumnTimestamp ColumnType = "timestamp"
+	ColumnString    ColumnType = "string"
+)
+
+// columnTypes tell whether a value is of a column type, from the most to the
+// least specific. Values of none of them are strings.
+var columnTypes = []struct {
+	typ   ColumnType
+	match func(string) bool
+}{
+	{ColumnInteger, func(v string) bool {
+		_, err := strconv.ParseInt(v, 10, 64)
+		return err == nil
+	}},
+	{ColumnNumber, func(v string) bool {
+		_, err := strconv.ParseFloat(v, 64)
+		return err == nil
+	}},
+	{ColumnBoolean, func(v string) bool {
+		_, err := strconv.ParseBool(v)
+		return err == nil
+	}},
+	{ColumnTimestamp, func(v string) bool {
+		for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02"} {
+			if _, err := time.Parse(layout, v); err == nil {
+				return true
+			}
+		}
+		return false
+	}},
+}
+
+// Preview is what a task reads from the beginning of its file.
+type Preview struct {
+	// Header is the header of the file, if the dialect has one.
+	Header Header `json:"header,omitempty"`
+	// Records are the first records of the file.
+	Records []PreviewRecord `json:"records"`
+	// More tells that the file has records after the previewed ones.
+	More bool `json:"more"`
+	// Columns describe the values of the previewed records.
+	Columns []Column `json:"columns"`
+	// DryRun is the outcome of processing the records, if requested.
+	DryRun *DryRun `json:"dryRun,omitempty"`
+}
+
+// PreviewRecord is a record of a preview.
+type PreviewRecord struct {
+	// Row is the number of the record in the file, starting at 1 and not counting the header.
+	Row    int64    `json:"row"`
+	Fields []string `json:"fields,omitempty"`
+	// Error tells why the record couldn't be parsed, in which case it has no fields.
+	Error *Error `json:"error,omitempty"`
+}
+
+// Column describes the values of a column in the records of a preview.
+type Column struct {
+	// Name is the name of the column in the header, if any.
+	Name string     `json:"name,omitempty"`
+	Type ColumnType `json:"type"`
+	// Empty is the number of records whose value is blank or missing.
+	Empty int `json:"empty"`
+}
+
+// DryRun is the outcome of processing the records of a preview.
+type DryRun struct {
+	// Header is what the processor would write at the top of the output, if anything.
+	Header []string `json:"header,omitempty"`
+	// Results are the outcomes of the records which could be parsed.
+	Results []DryRunResult `json:"results"`
+	// Error is the error which stopped the processor, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// DryRunResult is the outcome of processing a record of a preview.
+type DryRunResult struct {
+	Row int64 `json:"row"`
+	// Output is what would be written to the output file, nothing if nil.
+	Output []string `json:"output"`
+	Error  string   `json:"error,omitempty"`
+}
+
+// dryRunKey is the context key telling processors they are dry run.
+type dryRunKey struct{}
+
+// IsDryRun reports whether the processor is run on a preview, in which case
+// it should return its output without any other side effect.
+func IsDryRun(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(dryRunKey{}).(bool)
+	return dryRun
+}
+
+// Preview reads the first n records of the file of the task the way it
+// processes them, from the start of its range, and infers the types of the columns from them. With dryRun
+// the records are also handed to a new instance of the processor of the task,
+// what it returns being kept in the preview instead of written. The task is
+// left untouched, whatever its status.
+func (t *Task) Preview(ctx context.Context, n int, dryRun bool) (*Preview, error) {
+	file, err := os.Open(t.FilePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	p := &Preview{Records: []PreviewRecord{}}
+
+	var offset int64
+	if t.Config.Dialect.Header {
+		p.Header, offset, err = t.readHeader(file)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Records before the range are skipped, rows still being numbered from
+	// the start of the file.
+	if offset, err = t.seekRange(file, offset, p.Header); err != nil {
+		return nil, err
+	}
+	records, err := t.Config.Dialect.records(file, offset, p.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	r := t.Config.Range
+	first := r.skippedRows() + 1
+	start := offset
+	for row := first; !r.ended(row, start); row++ {
+		fields, end, err := records.read()
+		if err == io.EOF {
+			break
+		}
+		if row-first >= int64(n) {
+			p.More = true
+			break
+		}
+
+		var perr *csv.ParseError
+		if errors.As(err, &perr) {
+			rerr, _ := rowError(file, row, start, end, parseErrorColumn(perr), perr.Err)
+			p.Records = append(p.Records, PreviewRecord{Row: row, Error: rerr})
+		} else if err != nil {
+			return nil, err
+		} else {
+			p.Records = append(p.Records, PreviewRecord{Row: row, Fields: fields})
+		}
+		start = end
+	}
+
+	p.Columns = inferColumns(p.Header, p.Records)
+	if dryRun {
+		p.DryRun = t.dryRun(ctx, p.Header, p.Records)
+	}
+	return p, nil
+}
+
+// dryRun hands the records which could be parsed to a new instance of the
+// processor of the task, and returns what it made of them.
+func (t *Task) dryRun(ctx context.Context, header Header, records []PreviewRecord) *DryRun {
+	ctx = context.WithValue(ctx, dryRunKey{}, true)
+	d := &DryRun{Results: []DryRunResult{}}
+
+	processor, err := NewProcessor(t.Config.Processor)
+	if err != nil {
+		d.Error = err.Error()
+		return d
+	}
+	if err := processor.Init(ctx, t.ID); err != nil {
+		d.Error = err.Error()
+		return d
+	}
+	defer func() {
+		if err := processor.Close(); err != nil {
+			log.Printf("[%s] closing dry run processor: %v\n", t.ID, err)
+		}
+	}()
+
+	if hp, ok := processor.(HeaderProcessor); ok && header != nil {
+		if d.Header, err = hp.Header(ctx, header); err != nil {
+			d.Error = err.Error()
+			return d
+		}
+	}
+
+	for _, r := range records {
+		if r.Error != nil {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			d.Error = err.Error()
+			break
+		}
+
+		res := DryRunResult{Row: r.Row}
+		if output, err := processor.Process(ctx, r.Fields); err != nil {
+			res.Error = err.Error()
+		} else {
+			res.Output = output
+		}
+		d.Results = append(d.Results, res)
+	}
+	return d
+}
+
+// inferColumns describes the columns of the records, which are as many as the
+// fields of the header or of the widest record.
+func inferColumns(header Header, records []PreviewRecord) []Column {
+	width := len(header)
+	for _, r := range records {
+		if len(r.Fields) > width {
+			width = len(r.Fields)
+		}
+	}
+
+	columns := make([]Column, width)
+	for i := range columns {
+		if i < len(header) {
+			columns[i].Name = header[i]
+		}
+
+		// matches[j] tells whether all the values so far are of columnTypes[j].
+		matches := make([]bool, len(columnTypes))
+		for j := range matches {
+			matches[j] = true
+		}
+		seen := false
+
+		for _, r := range records {
+			if r.Error != nil {
+				continue
+			}
+
+			var v string
+			if i < len(r.Fields) {
+				v = strings.TrimSpace(r.Fields[i])
+			}
+			if v == "" {
+				columns[i].Empty++
+				continue
+			}
+
+			seen = true
+			for j, ct := range columnTypes {
+				matches[j] = matches[j] && ct.match(v)
+			}
+		}
+
+		columns[i].Type = ColumnEmpty
+		if seen {
+			columns[i].Type = ColumnString
+			for j, ct := range columnTypes {
+				if matches[j] {
+					columns[i].Type = ct.typ
+					break
+				}
+			}
+		}
+	}
+	return columns
+}
@@ -0,0 +1,124 @@
+package task
+
+import (
+	"context"
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+func TestPreview(t *testing.T) {
+	data := "id;price;paid;at;note\n1;9.5;true;2020-09-01;\n2;10;false;2020-09-02T10:00:00Z;x\n3;\"a\"b;true;2020-09-03;\n4;7;1;2020-09-04;y\n5;1;0;2020-09-05;z\n"
+	tk := newTestTask("preview", t, withData(data), withConfig(Config{Dialect: Dialect{Delimiter: ';', Header: true}}))
+
+	p, err := tk.Preview(context.Background(), 4, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(p.Header, Header{"id", "price", "paid", "at", "note"}) {
+		t.Fatalf("incorrect header: %v", p.Header)
+	}
+	if len(p.Records) != 4 || !p.More || p.DryRun != nil {
+		t.Fatalf("incorrect preview: %+v", p)
+	}
+	if r := p.Records[1]; r.Row != 2 || !reflect.DeepEqual(r.Fields, []string{"2", "10", "false", "2020-09-02T10:00:00Z", "x"}) {
+		t.Fatalf("incorrect record: %+v", r)
+	}
+	if r := p.Records[2]; r.Row != 3 || r.Fields != nil || r.Error == nil || r.Error.Row != 3 || r.Error.Line != `3;"a"b;true;2020-09-03;` {
+		t.Fatalf("incorrect malformed record: %+v", r)
+	}
+
+	expected := []Column{
+		{Name: "id", Type: ColumnInteger},
+		{Name: "price", Type: ColumnNumber},
+		{Name: "paid", Type: ColumnBoolean},
+		{Name: "at", Type: ColumnTimestamp},
+		{Name: "note", Type: ColumnString, Empty: 1},
+	}
+	if !reflect.DeepEqual(p.Columns, expected) {
+		t.Fatalf("incorrect columns. expected: %+v; got: %+v", expected, p.Columns)
+	}
+
+	p, err = tk.Preview(context.Background(), 10, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Records) != 5 || p.More {
+		t.Fatalf("incorrect preview of the whole file: %+v", p)
+	}
+	if c := p.Columns[2]; c.Type != ColumnBoolean {
+		t.Fatalf("incorrect type of 0 and 1 booleans: %+v", c)
+	}
+}
+
+func TestPreviewRange(t *testing.T) {
+	data := "id\n1\n2\n3\n4\n5\n"
+	tk := newTestTask("preview", t, withData(data), withConfig(Config{Dialect: Dialect{Header: true}, Range: Range{StartRow: 3, EndRow: 4}}))
+
+	p, err := tk.Preview(context.Background(), 10, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Records) != 2 || p.More || p.Records[0].Row != 3 || p.Records[0].Fields[0] != "3" || p.Records[1].Row != 4 {
+		t.Fatalf("incorrect preview of the range: %+v", p)
+	}
+
+	p, err = tk.Preview(context.Background(), 1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Records) != 1 || !p.More || p.Records[0].Row != 3 {
+		t.Fatalf("incorrect preview of the start of the range: %+v", p)
+	}
+
+	tk = newTestTask("preview", t, withData(data), withConfig(Config{Dialect: Dialect{Header: true}, Range: Range{StartOffset: int64(len("id\n1\n"))}}))
+	p, err = tk.Preview(context.Background(), 10, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Records) != 4 || p.Records[0].Row != 1 || p.Records[0].Fields[0] != "2" {
+		t.Fatalf("incorrect preview of the byte range: %+v", p)
+	}
+}
+
+func TestPreviewWithoutHeader(t *testing.T) {
+	tk := newTestTask("preview", t, withData("1,,x\n2,,y,z\n"), withConfig(Config{Dialect: Dialect{FieldsPerRecord: -1}}))
+
+	p, err := tk.Preview(context.Background(), DefaultPreviewRecords, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Column{{Type: ColumnInteger}, {Type: ColumnEmpty, Empty: 2}, {Type: ColumnString}, {Type: ColumnString, Empty: 1}}
+	if p.Header != nil || !reflect.DeepEqual(p.Columns, expected) {
+		t.Fatalf("incorrect preview: %+v", p)
+	}
+}
+
+func TestPreviewDryRun(t *testing.T) {
+	tk := newTestTask("preview", t, withData("a,b\nx,y\nfail,z\n"), withConfig(Config{Processor: "test-stub", Dialect: Dialect{Header: true}}))
+
+	p, err := tk.Preview(context.Background(), DefaultPreviewRecords, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &DryRun{
+		Header: []string{"a", "b"},
+		Results: []DryRunResult{
+			{Row: 1, Output: []string{"dry-run", "x", "y"}},
+			{Row: 2, Error: "failed"},
+		},
+	}
+	if !reflect.DeepEqual(p.DryRun, expected) {
+		t.Fatalf("incorrect dry run. expected: %+v; got: %+v", expected, p.DryRun)
+	}
+
+	if s := tk.Status(); s != TaskNotStarted {
+		t.Fatalf("expected the task to be left not started, got: %s", s)
+	}
+	if _, err := ioutil.ReadFile(tk.OutputPath()); err == nil {
+		t.Fatal("expected no output file")
+	}
+}
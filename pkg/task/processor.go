@@ -0,0 +1,119 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultProcessor is the name of the processor used when none is specified.
+const DefaultProcessor = "simulate"
+
+// ErrUnknownProcessor is returned when no processor is registered with the requested name.
+var ErrUnknownProcessor = errors.New("unknown processor")
+
+// Processor does the actual work on the records of a task.
+// A new Processor is created for every task, so it can safely keep per-task state.
+// The context passed to Init and Process is cancelled once the task gets
+// terminated or times out, long running work should give up when it is done.
+type Processor interface {
+	// Init is called once before the first record is processed.
+	Init(ctx context.Context, taskID string) error
+	// Process is called for every record of the uploaded file. The returned
+	// record is written to the output file of the task, unless it is nil.
+	Process(ctx context.Context, record []string) ([]string, error)
+	// Flush is called whenever the task gets paused and after the last record.
+	Flush() error
+	// Close is called once the task stops, whether it finished or not.
+	Close() error
+}
+
+// HeaderProcessor is implemented by processors which want the column names of
+// files uploaded with a header, so that records can be addressed by name.
+type HeaderProcessor interface {
+	Processor
+	// Header is called with the header of the file after Init, every time the
+	// task starts processing. The returned header is written at the top of the
+	// output file of the task, unless it is nil.
+	Header(ctx context.Context, header Header) ([]string, error)
+}
+
+// ProcessorFactory returns a new instance of a Processor.
+type ProcessorFactory func() Processor
+
+var (
+	processorsMu sync.RWMutex
+	processors   = make(map[string]ProcessorFactory)
+)
+
+func init() {
+	RegisterProcessor(DefaultProcessor, func() Processor { return simulateProcessor{} })
+}
+
+// RegisterProcessor makes a processor available by the provided name.
+// It panics if the factory is nil or a processor is already registered with that name.
+func RegisterProcessor(name string, factory ProcessorFactory) {
+	processorsMu.Lock()
+	defer processorsMu.Unlock()
+
+	if factory == nil {
+		panic("task: RegisterProcessor factory is nil")
+	}
+	if _, dup := processors[name]; dup {
+		panic("task: RegisterProcessor called twice for processor " + name)
+	}
+	processors[name] = factory
+}
+
+// NewProcessor returns a new instance of the processor registered with the given name.
+func NewProcessor(name string) (Processor, error) {
+	processorsMu.RLock()
+	factory, ok := processors[name]
+	processorsMu.RUnlock()
+
+	if !ok {
+		return nil, ErrUnknownProcessor
+	}
+	return factory(), nil
+}
+
+// Processors returns a sorted list of the names of registered processors.
+func Processors() []string {
+	processorsMu.RLock()
+	defer processorsMu.RUnlock()
+
+	names := make([]string, 0, len(processors))
+	for name := range processors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// simulateProcessor pretends to do some work by sleeping a random amount for
+// every record, which it outputs unchanged along with the header.
+type simulateProcessor struct{}
+
+func (simulateProcessor) Init(context.Context, string) error { return nil }
+
+func (simulateProcessor) Header(_ context.Context, header Header) ([]string, error) {
+	return header, nil
+}
+
+func (simulateProcessor) Process(ctx context.Context, record []string) ([]string, error) {
+	r := rand.Intn(1000)
+
+	select {
+	case <-time.After(time.Duration(r) * time.Millisecond):
+		return record, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (simulateProcessor) Flush() error { return nil }
+
+func (simulateProcessor) Close() error { return nil }
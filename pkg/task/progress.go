@@ -0,0 +1,192 @@
+package task
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"os"
+	"time"
+)
+
+// precountLimit is the largest file size for which records are counted upfront
+// instead of estimating the total from the bytes read so far.
+const precountLimit = 4 << 20
+
+// Progress is a snapshot of how far a task has got through its file.
+type Progress struct {
+	Processed int64 `json:"processed"`
+	Failed    int64 `json:"failed"`
+	Skipped   int64 `json:"skipped"`
+	// Quarantined is the number of records written to the quarantine file,
+	// whether they were malformed or failed processing.
+	Quarantined int64 `json:"quarantined"`
+	// Retries is the number of times records were processed again after a retryable error.
+	Retries int64 `json:"retries"`
+	// Total is the number of records in the file. It is an estimate based on
+	// the bytes read so far unless TotalExact is set.
+	Total      int64   `json:"total"`
+	TotalExact bool    `json:"totalExact"`
+	Percent    float64 `json:"percent"`
+	BytesRead  int64   `json:"bytesRead"`
+	BytesTotal int64   `json:"bytesTotal"`
+	// Throughput is the number of records handled per second while running.
+	Throughput float64 `json:"throughput"`
+	// ETA is the estimated number of seconds left, zero if unknown or not running.
+	ETA float64 `json:"eta"`
+}
+
+// Progress returns the current progress of the task.
+func (t *Task) Progress() Progress {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.progress()
+}
+
+// progress returns the current progress of the task. The caller must hold the mutex.
+func (t *Task) progress() Progress {
+	p := Progress{
+		Processed:   t.processed,
+		Failed:      t.failed,
+		Skipped:     t.skipped,
+		Quarantined: t.quarantined,
+		Retries:     t.retries,
+		Total:       t.total,
+		TotalExact:  t.totalExact,
+		BytesRead:   t.offset,
+		BytesTotal:  t.size,
+	}
+
+	// The bytes of tasks restricted to a range are counted from its start,
+	// up to its end when known.
+	r := t.Config.Range
+	if r.EndOffset > 0 && r.EndOffset < p.BytesTotal {
+		p.BytesTotal = r.EndOffset
+	}
+	if t.rangeStart > 0 {
+		p.BytesRead -= t.rangeStart
+		p.BytesTotal -= t.rangeStart
+	}
+	if p.BytesRead > p.BytesTotal {
+		p.BytesRead = p.BytesTotal
+	}
+
+	switch {
+	case t.State == TaskFinished:
+		p.Total, p.Percent = t.record, 100
+	case t.totalExact && t.total > 0:
+		p.Percent = float64(t.record) / float64(t.total) * 100
+	case r.EndRow > 0:
+		p.Total = r.EndRow - r.skippedRows()
+		p.Percent = float64(t.record) / float64(p.Total) * 100
+	case p.BytesRead > 0 && p.BytesTotal > 0:
+		p.Total = t.record * p.BytesTotal / p.BytesRead
+		p.Percent = float64(p.BytesRead) / float64(p.BytesTotal) * 100
+	}
+
+	active := t.activeTime
+	if !t.activeSince.IsZero() {
+		active += time.Since(t.activeSince)
+	}
+	if active > 0 {
+		p.Throughput = float64(t.record) / active.Seconds()
+	}
+
+	if t.State == TaskRunning && p.Throughput > 0 && p.Total > t.record {
+		p.ETA = float64(p.Total-t.record) / p.Throughput
+	}
+
+	return p
+}
+
+// startClock starts measuring the time the task spends running.
+func (t *Task) startClock() {
+	t.mutex.Lock()
+	t.activeSince = time.Now()
+	t.mutex.Unlock()
+}
+
+// stopClock stops measuring the running time, adding it to the total.
+func (t *Task) stopClock() {
+	t.mutex.Lock()
+	if !t.activeSince.IsZero() {
+		t.activeTime += time.Since(t.activeSince)
+		t.activeSince = time.Time{}
+	}
+	t.mutex.Unlock()
+}
+
+// measure records the size of the file and counts the records of its range
+// if it is small enough, otherwise the total is estimated while processing.
+// The offset index built along the way is saved if the file has enough rows.
+func (t *Task) measure(file *os.File) error {
+	fi, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	// The rows of a range of bytes aren't known until they are read.
+	r := t.Config.Range
+	t.mutex.Lock()
+	t.size = fi.Size()
+	countable := !t.totalExact && t.size <= precountLimit && r.StartOffset == 0 && r.EndOffset == 0
+	t.mutex.Unlock()
+
+	if !countable {
+		return nil
+	}
+
+	total, idx, err := countRecords(file, t.Config.Dialect, fi.Size())
+	if err != nil {
+		// The total of a malformed file is estimated instead.
+		return nil
+	}
+	if len(idx.Offsets) > 1 {
+		t.saveIndex(idx)
+	}
+
+	t.mutex.Lock()
+	t.total, t.totalExact = r.count(total), true
+	t.mutex.Unlock()
+
+	return nil
+}
+
+// countRecords returns the number of records in the file, not counting the
+// header, along with the offset index of the file. Malformed records are
+// counted as well.
+func countRecords(file *os.File, d Dialect, size int64) (int64, *offsetIndex, error) {
+	records, err := d.records(file, 0, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	records.csv.ReuseRecord = true
+
+	idx := &offsetIndex{Size: size, Dialect: d}
+	var n, offset int64
+	for {
+		// The header isn't a row, the record after it being row 1.
+		row := n + 1
+		if d.Header {
+			row = n
+		}
+		if row > 0 {
+			idx.add(row, offset)
+		}
+
+		_, end, err := records.read()
+		if err == io.EOF {
+			break
+		}
+		var perr *csv.ParseError
+		if err != nil && !errors.As(err, &perr) {
+			return 0, nil, err
+		}
+		n++
+		offset = end
+	}
+
+	if d.Header && n > 0 {
+		n--
+	}
+	return n, idx, nil
+}
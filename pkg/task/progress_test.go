@@ -0,0 +1,71 @@
+package task
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProgressEstimate(t *testing.T) {
+	tk := &Task{
+		State:      TaskRunning,
+		record:     10,
+		processed:  8,
+		skipped:    2,
+		offset:     100,
+		size:       1000,
+		activeTime: 5 * time.Second,
+	}
+
+	p := tk.Progress()
+	if p.TotalExact || p.Total != 100 || p.Percent != 10 {
+		t.Fatalf("incorrect estimate: %+v", p)
+	}
+	if p.Throughput != 2 || p.ETA != 45 {
+		t.Fatalf("incorrect throughput or ETA: %+v", p)
+	}
+}
+
+func TestProgressExact(t *testing.T) {
+	tk := &Task{
+		State:      TaskPaused,
+		record:     25,
+		processed:  25,
+		total:      100,
+		totalExact: true,
+		offset:     100,
+		size:       1000,
+		activeTime: 5 * time.Second,
+	}
+
+	p := tk.Progress()
+	if p.Total != 100 || p.Percent != 25 {
+		t.Fatalf("incorrect progress: %+v", p)
+	}
+	if p.ETA != 0 {
+		t.Fatalf("expected no ETA for paused task: %+v", p)
+	}
+}
+
+func TestProgressRange(t *testing.T) {
+	tk := &Task{
+		Config:     Config{Range: Range{StartRow: 11, EndRow: 60}},
+		State:      TaskRunning,
+		record:     10,
+		rangeStart: 100,
+		offset:     300,
+		size:       1000,
+	}
+
+	p := tk.Progress()
+	if p.Total != 50 || p.Percent != 20 || p.BytesRead != 200 || p.BytesTotal != 900 {
+		t.Fatalf("incorrect progress of rows: %+v", p)
+	}
+
+	tk.Config.Range = Range{StartOffset: 100, EndOffset: 600}
+	tk.offset = 350
+
+	p = tk.Progress()
+	if p.Total != 20 || p.Percent != 50 || p.BytesRead != 250 || p.BytesTotal != 500 {
+		t.Fatalf("incorrect progress of bytes: %+v", p)
+	}
+}
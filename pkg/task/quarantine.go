@@ -0,0 +1,113 @@
+package task
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"os"
+	"strconv"
+)
+
+// ErrNothingQuarantined is returned when replaying a task which has no quarantined records.
+var ErrNothingQuarantined = errors.New("no quarantined records")
+
+// quarantineHeader is the first record of every quarantine file.
+var quarantineHeader = []string{"row", "error", "line"}
+
+// QuarantinePath returns the path of the file holding the quarantined records,
+// which is kept next to the uploaded file.
+func (t *Task) QuarantinePath() string {
+	return t.FilePath + ".quarantine.csv"
+}
+
+// QuarantineSize returns the size of the quarantine file as of the last time
+// the task flushed it, so that it always ends with a complete record.
+func (t *Task) QuarantineSize() int64 {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.quarantineOffset
+}
+
+// openQuarantine opens the quarantine file at the checkpointed offset.
+func (t *Task) openQuarantine() error {
+	q, err := openOutput(t.QuarantinePath(), OutputCSV, t.quarantineOffset)
+	if err != nil {
+		return err
+	}
+	t.quarantine = q
+
+	if t.quarantineOffset == 0 {
+		return q.write(quarantineHeader)
+	}
+	return nil
+}
+
+// quarantineRecord writes the record which caused the error to the quarantine file.
+func (t *Task) quarantineRecord(e *Error) error {
+	return t.quarantine.write([]string{strconv.FormatInt(e.Row, 10), e.Message, e.Line})
+}
+
+// WriteReplay writes the quarantined records to w as they were in the uploaded
+// file, preceded by its header if it has one, so that they can be processed
+// again by another task. It returns the number of records written.
+func (t *Task) WriteReplay(w io.Writer) (int, error) {
+	size := t.QuarantineSize()
+	if size == 0 {
+		return 0, ErrNothingQuarantined
+	}
+
+	q, err := os.Open(t.QuarantinePath())
+	if err != nil {
+		return 0, err
+	}
+	defer q.Close()
+
+	csvR := csv.NewReader(io.NewSectionReader(q, 0, size))
+	if _, err := csvR.Read(); err != nil {
+		return 0, err
+	}
+
+	if t.Config.Dialect.Header {
+		if err := t.copyHeader(w); err != nil {
+			return 0, err
+		}
+	}
+
+	n := 0
+	for {
+		record, err := csvR.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return n, err
+		}
+
+		if _, err := io.WriteString(w, record[2]+"\n"); err != nil {
+			return n, err
+		}
+		n++
+	}
+
+	if n == 0 {
+		return 0, ErrNothingQuarantined
+	}
+	return n, nil
+}
+
+// copyHeader writes the raw header of the uploaded file to w.
+func (t *Task) copyHeader(w io.Writer) error {
+	file, err := os.Open(t.FilePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, end, err := t.readHeader(file)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(w, io.NewSectionReader(file, 0, end))
+	return err
+}
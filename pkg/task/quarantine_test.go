@@ -0,0 +1,61 @@
+package task
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+// rejectProcessor fails the records with the given id, and outputs the other ones.
+type rejectProcessor struct {
+	id string
+}
+
+func (rejectProcessor) Init(context.Context, string) error { return nil }
+
+func (p rejectProcessor) Process(_ context.Context, record []string) ([]string, error) {
+	if record[0] == p.id {
+		return nil, errors.New("rejected")
+	}
+	return record, nil
+}
+
+func (rejectProcessor) Flush() error { return nil }
+
+func (rejectProcessor) Close() error { return nil }
+
+func TestQuarantineReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "replay.csv")
+	if err := ioutil.WriteFile(path, []byte("id,name\n1,a\n2,b\"\n3,c\n4,d\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tk, err := NewTask("replay", path, Config{Malformed: MalformedQuarantine, Dialect: Dialect{Header: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tk.processor = rejectProcessor{id: "3"}
+
+	if _, err := tk.WriteReplay(&bytes.Buffer{}); err != ErrNothingQuarantined {
+		t.Fatalf("expected nothing quarantined before running, got: %v", err)
+	}
+
+	tk.Run(Cause{})
+	waitStatus(tk, TaskFinished, t)
+
+	if p := tk.Progress(); p.Processed != 2 || p.Failed != 1 || p.Quarantined != 2 {
+		t.Fatalf("incorrect progress: %+v", p)
+	}
+
+	var b bytes.Buffer
+	n, err := tk.WriteReplay(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "id,name\n2,b\"\n3,c\n"; n != 2 || b.String() != expected {
+		t.Fatalf("incorrect replay of %d records. expected: %q; got: %q", n, expected, b.String())
+	}
+}
@@ -0,0 +1,124 @@
+package task
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ErrInvalidRange is returned when the range of a task can't be used.
+var ErrInvalidRange = errors.New("invalid range")
+
+// Range restricts a task to part of its file, either by rows or by bytes.
+// Rows are numbered like the rows of errors, from 1 and not counting the header.
+type Range struct {
+	// StartRow is the first record processed, the first one of the file if zero.
+	StartRow int64 `json:"startRow,omitempty"`
+	// EndRow is the last record processed, the last one of the file if zero.
+	EndRow int64 `json:"endRow,omitempty"`
+	// StartOffset is the byte offset of the first record processed. It must
+	// be the start of a record, e.g. the offset of a row error.
+	StartOffset int64 `json:"startOffset,omitempty"`
+	// EndOffset is the byte offset before which the records processed start,
+	// the end of the file if zero.
+	EndOffset int64 `json:"endOffset,omitempty"`
+}
+
+// validate returns an error wrapping ErrInvalidRange if the range is empty or
+// mixes rows and bytes.
+func (r Range) validate() error {
+	switch {
+	case r.StartRow < 0 || r.EndRow < 0 || r.StartOffset < 0 || r.EndOffset < 0:
+		return fmt.Errorf("%w: rows and offsets can't be negative", ErrInvalidRange)
+	case (r.StartRow > 0 || r.EndRow > 0) && (r.StartOffset > 0 || r.EndOffset > 0):
+		return fmt.Errorf("%w: rows and offsets can't be combined", ErrInvalidRange)
+	case r.EndRow > 0 && r.EndRow < r.StartRow:
+		return fmt.Errorf("%w: end row before start row", ErrInvalidRange)
+	case r.EndOffset > 0 && r.EndOffset <= r.StartOffset:
+		return fmt.Errorf("%w: end offset not after start offset", ErrInvalidRange)
+	}
+	return nil
+}
+
+// skippedRows returns the number of rows before the range. They are unknown,
+// and not counted, for ranges of bytes.
+func (r Range) skippedRows() int64 {
+	if r.StartRow > 1 {
+		return r.StartRow - 1
+	}
+	return 0
+}
+
+// count returns the number of rows of the range in a file of n rows.
+func (r Range) count(n int64) int64 {
+	if r.EndRow > 0 && r.EndRow < n {
+		n = r.EndRow
+	}
+	if n -= r.skippedRows(); n < 0 {
+		return 0
+	}
+	return n
+}
+
+// ended reports whether the record at the row, starting at the offset, is
+// after the range.
+func (r Range) ended(row, offset int64) bool {
+	return (r.EndRow > 0 && row > r.EndRow) || (r.EndOffset > 0 && offset >= r.EndOffset)
+}
+
+// row returns the number of the last record the task handled in its file.
+func (t *Task) row() int64 {
+	return t.Config.Range.skippedRows() + t.record
+}
+
+// seekRange returns the offset of the first record of the range of the task,
+// the records starting at the offset being the first ones after the header.
+// Rows before the range are skipped without parsing all of them when the
+// offset index of the file covers them, the index being extended otherwise.
+func (t *Task) seekRange(file *os.File, offset int64, header Header) (int64, error) {
+	r := t.Config.Range
+	if r.StartOffset > offset {
+		return r.StartOffset, nil
+	}
+	if r.StartRow <= 1 {
+		return offset, nil
+	}
+
+	fi, err := file.Stat()
+	if err != nil {
+		return 0, err
+	}
+
+	idx := t.loadIndex(fi.Size(), offset)
+	row, offset := idx.nearest(r.StartRow)
+
+	records, err := t.Config.Dialect.records(file, offset, header)
+	if err != nil {
+		return 0, err
+	}
+	records.csv.ReuseRecord = true
+
+	extended := false
+	for ; row < r.StartRow; row++ {
+		_, end, err := records.read()
+		if err == io.EOF {
+			break
+		}
+		var perr *csv.ParseError
+		if err != nil && !errors.As(err, &perr) {
+			return 0, err
+		}
+
+		offset = end
+		if idx.add(row+1, offset) {
+			extended = true
+		}
+	}
+
+	if extended {
+		t.saveIndex(idx)
+	}
+	return offset, nil
+}
@@ -0,0 +1,177 @@
+package task
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeRows writes a file with a header and n rows, returning its path.
+func writeRows(n int, t *testing.T) string {
+	var b strings.Builder
+	b.WriteString("id,name\n")
+	for i := 1; i <= n; i++ {
+		fmt.Fprintf(&b, "%d,row %d\n", i, i)
+	}
+
+	path := filepath.Join(t.TempDir(), "rows.csv")
+	if err := ioutil.WriteFile(path, []byte(b.String()), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRangeRows(t *testing.T) {
+	path := writeRows(2500, t)
+
+	tk, err := NewTask("rows", path, Config{
+		Dialect:   Dialect{Header: true},
+		Malformed: MalformedQuarantine,
+		Range:     Range{StartRow: 1500, EndRow: 2200},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tk.processor = rejectProcessor{id: "1600"}
+
+	tk.Run(Cause{})
+	waitStatus(tk, TaskFinished, t)
+
+	p := tk.Progress()
+	if p.Total != 701 || !p.TotalExact || p.Processed != 700 || p.Quarantined != 1 || p.Percent != 100 {
+		t.Fatalf("incorrect progress: %+v", p)
+	}
+	if errs := tk.RowErrors(); len(errs) != 1 || errs[0].Row != 1600 {
+		t.Fatalf("incorrect row errors: %+v", errs)
+	}
+
+	b, err := ioutil.ReadFile(tk.OutputPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+	if len(lines) != 700 || lines[0] != "1500,row 1500" || lines[699] != "2200,row 2200" {
+		t.Fatalf("incorrect output: %d lines from %q to %q", len(lines), lines[0], lines[len(lines)-1])
+	}
+
+	// The file was small enough to be indexed while counting its records.
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if idx := tk.loadIndex(fi.Size(), int64(len("id,name\n"))); len(idx.Offsets) != 3 {
+		t.Fatalf("expected the index of the file, got: %+v", idx.Offsets)
+	}
+}
+
+func TestRangeIndex(t *testing.T) {
+	path := writeRows(3000, t)
+
+	tk, err := NewTask("index", path, Config{Dialect: Dialect{Header: true}, Range: Range{StartRow: 2500}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	header, first, err := tk.readHeader(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	offset, err := tk.seekRange(file, first, header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if line := readRow(file, offset, t); line != "2500,row 2500" {
+		t.Fatalf("incorrect start of range: %q", line)
+	}
+
+	fi, _ := file.Stat()
+	idx := tk.loadIndex(fi.Size(), first)
+	if len(idx.Offsets) != 3 || readRow(file, idx.Offsets[2], t) != "2001,row 2001" {
+		t.Fatalf("expected the skipped rows to be indexed, got: %+v", idx.Offsets)
+	}
+
+	// Rows are skipped from the closest indexed one.
+	idx.Offsets[2] = idx.Offsets[1]
+	tk.saveIndex(idx)
+	if offset, err = tk.seekRange(file, first, header); err != nil {
+		t.Fatal(err)
+	}
+	if line := readRow(file, offset, t); line != "1500,row 1500" {
+		t.Fatalf("expected the index to be used, got: %q", line)
+	}
+
+	// Indexes of other dialects are ignored.
+	tk.Config.Dialect.TrimLeadingSpace = true
+	if offset, err = tk.seekRange(file, first, header); err != nil {
+		t.Fatal(err)
+	}
+	if line := readRow(file, offset, t); line != "2500,row 2500" {
+		t.Fatalf("expected the index to be ignored, got: %q", line)
+	}
+}
+
+func readRow(file *os.File, offset int64, t *testing.T) string {
+	t.Helper()
+
+	b := make([]byte, 64)
+	n, err := file.ReadAt(b, offset)
+	if n == 0 {
+		t.Fatal(err)
+	}
+	return string(bytes.SplitN(b[:n], []byte("\n"), 2)[0])
+}
+
+func TestRangeBytes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bytes.csv")
+	data := "id,name\n1,a\n2,b\n3,c\n4,d\n"
+	if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	start, end := int64(strings.Index(data, "2,b")), int64(strings.Index(data, "4,d"))
+	tk, err := NewTask("bytes", path, Config{Dialect: Dialect{Header: true}, Range: Range{StartOffset: start, EndOffset: end}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tk.processor = rejectProcessor{}
+
+	tk.Run(Cause{})
+	waitStatus(tk, TaskFinished, t)
+
+	if p := tk.Progress(); p.Processed != 2 || p.BytesRead != end-start || p.BytesTotal != end-start {
+		t.Fatalf("incorrect progress: %+v", p)
+	}
+
+	b, err := ioutil.ReadFile(tk.OutputPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "2,b\n3,c\n"; string(b) != expected {
+		t.Fatalf("incorrect output. expected: %q; got: %q", expected, b)
+	}
+}
+
+func TestRangeInvalid(t *testing.T) {
+	for _, r := range []Range{
+		{StartRow: -1},
+		{StartRow: 10, EndRow: 5},
+		{StartOffset: 10, EndOffset: 10},
+		{StartRow: 2, EndOffset: 100},
+	} {
+		if _, err := NewTask("invalid", "invalid.csv", Config{Range: r}); !errors.Is(err, ErrInvalidRange) {
+			t.Fatalf("expected invalid range for %+v, got: %v", r, err)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"log"
+	"math/rand"
+	"time"
+)
+
+// DefaultRetryBackoff is the wait before the first retry when the policy doesn't set one.
+const DefaultRetryBackoff = 100 * time.Millisecond
+
+// ErrInvalidRetry is returned when the retry policy has negative values.
+var ErrInvalidRetry = errors.New("invalid retry policy")
+
+// retriesTotal counts the retries of all the tasks since the process started,
+// published as the "retries" expvar.
+var retriesTotal = expvar.NewInt("retries")
+
+// RetryPolicy tells how records failing with a retryable error are processed again.
+// The zero value means no retries.
+type RetryPolicy struct {
+	// MaxAttempts is the number of times a record is processed at most, including the first one.
+	MaxAttempts int `json:"maxAttempts,omitempty"`
+	// Backoff is the wait before the first retry, doubled for every following one.
+	Backoff time.Duration `json:"backoff,omitempty"`
+	// MaxBackoff caps the wait between two attempts, zero means no cap.
+	MaxBackoff time.Duration `json:"maxBackoff,omitempty"`
+}
+
+func (p RetryPolicy) validate() error {
+	if p.MaxAttempts < 0 || p.Backoff < 0 || p.MaxBackoff < 0 {
+		return ErrInvalidRetry
+	}
+	return nil
+}
+
+// backoff returns the wait after the given failed attempt, randomly picked
+// between half and all of the exponential backoff so that retries spread out.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.Backoff
+	if d == 0 {
+		d = DefaultRetryBackoff
+	}
+	for i := 1; i < attempt && (p.MaxBackoff == 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(d-half)+1))
+}
+
+// retryableError marks an error as transient.
+type retryableError struct {
+	err error
+}
+
+func (e *retryableError) Error() string { return e.err.Error() }
+
+func (e *retryableError) Unwrap() error { return e.err }
+
+// Retryable marks err as transient, so that processors can have the record
+// processed again according to the retry policy of the task.
+func Retryable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &retryableError{err: err}
+}
+
+// IsRetryable reports whether err was marked with Retryable, or is a temporary
+// error like the ones of the net package.
+func IsRetryable(err error) bool {
+	var rerr *retryableError
+	if errors.As(err, &rerr) {
+		return true
+	}
+
+	var temp interface{ Temporary() bool }
+	return errors.As(err, &temp) && temp.Temporary()
+}
+
+// abortError wraps an error which must stop the task instead of failing the record.
+type abortError struct {
+	err error
+}
+
+func (e *abortError) Error() string { return e.err.Error() }
+
+// processWithRetry hands the record to the processor, processing it again
+// while it fails with a retryable error and attempts are left.
+// Errors wrapped in an abortError must stop the task.
+func (t *Task) processWithRetry(ctx context.Context, record []string) ([]string, error) {
+	for attempt := 1; ; attempt++ {
+		result, err := t.processor.Process(ctx, record)
+		if err == nil || ctx.Err() != nil || attempt >= t.Config.Retry.MaxAttempts || !IsRetryable(err) {
+			return result, err
+		}
+
+		t.mutex.Lock()
+		t.retries++
+		row := t.record + 1
+		t.mutex.Unlock()
+		retriesTotal.Add(1)
+
+		wait := t.Config.Retry.backoff(attempt)
+		log.Printf("[%s] retrying row %d in %v after attempt %d: %v\n", t.ID, row, wait, attempt, err)
+
+		if err := t.waitRetry(ctx, wait); err != nil {
+			return nil, err
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// waitRetry waits before the next attempt, unless the context is done first.
+// If the task gets paused meanwhile, the pause is applied right away and the
+// record is retried once resumed.
+func (t *Task) waitRetry(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			return nil
+		case <-ctx.Done():
+			return nil
+		case <-t.pauseRequested:
+			if t.Status() != TaskPausing {
+				continue
+			}
+			if _, err := t.applyPause(ctx, "pause applied"); err != nil {
+				return &abortError{err: err}
+			}
+			return nil
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+// flakyProcessor fails every record with a retryable error until it was attempted enough times.
+type flakyProcessor struct {
+	failures int
+
+	mu       sync.Mutex
+	attempts map[string]int
+}
+
+func (p *flakyProcessor) Init(context.Context, string) error { return nil }
+
+func (p *flakyProcessor) Process(_ context.Context, record []string) ([]string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.attempts == nil {
+		p.attempts = make(map[string]int)
+	}
+	p.attempts[record[0]]++
+	if p.attempts[record[0]] <= p.failures {
+		return nil, Retryable(errors.New("downstream unavailable"))
+	}
+	return record, nil
+}
+
+func (p *flakyProcessor) Flush() error { return nil }
+
+func (p *flakyProcessor) Close() error { return nil }
+
+func TestRetry(t *testing.T) {
+	tk := newTestTask("retry", t)
+	tk.Config.Retry = RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}
+	tk.processor = &flakyProcessor{failures: 2}
+	before := retriesTotal.Value()
+
+	tk.Run(Cause{})
+	waitStatus(tk, TaskFinished, t)
+
+	if p := tk.Progress(); p.Processed != 4 || p.Retries != 8 {
+		t.Fatalf("incorrect progress: %+v", p)
+	}
+	if n := retriesTotal.Value() - before; n != 8 {
+		t.Fatalf("expected 8 retries in the metrics, got: %d", n)
+	}
+}
+
+func TestRetryExhausted(t *testing.T) {
+	tk := newTestTask("retry-exhausted", t)
+	tk.Config.Retry = RetryPolicy{MaxAttempts: 2, Backoff: time.Millisecond}
+	tk.processor = &flakyProcessor{failures: 2}
+
+	tk.Run(Cause{})
+	waitStatus(tk, TaskGotError, t)
+
+	if p := tk.Progress(); p.Failed != 1 || p.Retries != 1 {
+		t.Fatalf("incorrect progress: %+v", p)
+	}
+}
+
+func TestRetryWaitHonorsControl(t *testing.T) {
+	tk := newTestTask("retry-pause", t)
+	tk.Config.Retry = RetryPolicy{MaxAttempts: 2, Backoff: time.Hour}
+	tk.processor = &flakyProcessor{failures: 1}
+
+	tk.Run(Cause{})
+	time.Sleep(10 * time.Millisecond)
+
+	// The pause is applied in the middle of the backoff, and the record is
+	// retried right away once resumed.
+	tk.Pause(Cause{})
+	waitStatus(tk, TaskPaused, t)
+	tk.Resume(Cause{})
+	time.Sleep(10 * time.Millisecond)
+
+	if p := tk.Progress(); p.Processed != 1 {
+		t.Fatalf("expected the record to be retried on resume: %+v", p)
+	}
+
+	tk.Terminate(Cause{})
+	waitStatus(tk, TaskTerminated, t)
+}
+
+func TestRetryBackoff(t *testing.T) {
+	p := RetryPolicy{Backoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}
+
+	for attempt, max := range []time.Duration{100, 200, 300, 300} {
+		max *= time.Millisecond
+		if d := p.backoff(attempt + 1); d < max/2 || d > max {
+			t.Fatalf("backoff of attempt %d out of range: %v", attempt+1, d)
+		}
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	if IsRetryable(errors.New("bad record")) {
+		t.Fatal("plain errors must not be retryable")
+	}
+	if !IsRetryable(Retryable(errors.New("unavailable"))) {
+		t.Fatal("errors marked with Retryable must be retryable")
+	}
+	if !IsRetryable(&net.DNSError{IsTemporary: true}) {
+		t.Fatal("temporary errors must be retryable")
+	}
+}
@@ -0,0 +1,114 @@
+package task
+
+import (
+	"log"
+	"sync"
+)
+
+// Scheduler runs tasks with a bounded concurrency. Tasks wait in queued status
+// and start in FIFO order as the running ones free their slots.
+type Scheduler struct {
+	limit         int
+	releasePaused bool
+
+	mutex  sync.Mutex
+	active map[*Task]struct{}
+	queue  []*Task
+}
+
+// NewScheduler returns a scheduler running at most limit tasks at once, zero means no limit.
+// If releasePaused is set, paused tasks give up their slot and get queued again on resume.
+func NewScheduler(limit int, releasePaused bool) *Scheduler {
+	return &Scheduler{
+		limit:         limit,
+		releasePaused: releasePaused,
+		active:        make(map[*Task]struct{}),
+	}
+}
+
+// Submit queues a not started task, it runs as soon as a slot is free.
+// It returns a TransitionError if the task is not in not started status.
+func (s *Scheduler) Submit(t *Task, cause Cause) error {
+	t.mutex.Lock()
+	if err := t.check(ActionStart); err != nil {
+		t.mutex.Unlock()
+		return err
+	}
+	t.sched = s
+	t.setState(TaskQueued, cause)
+	t.mutex.Unlock()
+
+	t.notify(TaskQueued)
+	s.enqueue(t)
+	return nil
+}
+
+// enqueue adds a task to the end of the queue and starts as many tasks as there are free slots.
+func (s *Scheduler) enqueue(t *Task) {
+	s.mutex.Lock()
+	s.queue = append(s.queue, t)
+	s.mutex.Unlock()
+
+	log.Printf("[%s] queued\n", t.ID)
+	s.dispatch()
+}
+
+// dispatch starts queued tasks while there are free slots.
+func (s *Scheduler) dispatch() {
+	var next []*Task
+
+	s.mutex.Lock()
+	for len(s.queue) > 0 && (s.limit <= 0 || len(s.active) < s.limit) {
+		t := s.queue[0]
+		s.queue = s.queue[1:]
+		s.active[t] = struct{}{}
+		next = append(next, t)
+	}
+	s.mutex.Unlock()
+
+	for _, t := range next {
+		t.start(systemCause("slot available"))
+	}
+}
+
+// holds reports whether the task occupies a slot.
+func (s *Scheduler) holds(t *Task) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	_, ok := s.active[t]
+	return ok
+}
+
+// remove takes the task out of the queue, reporting whether it was still queued.
+func (s *Scheduler) remove(t *Task) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for i, q := range s.queue {
+		if q == t {
+			s.queue = append(s.queue[:i], s.queue[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// updated frees the slot of tasks which stopped, or got paused if paused tasks release their slot.
+func (s *Scheduler) updated(t *Task, status Status) {
+	if !status.final() && !(status == TaskPaused && s.releasePaused) {
+		return
+	}
+
+	// Tasks can also stop while queued, e.g. when terminated while waiting
+	// for a slot after being resumed.
+	if status.final() {
+		s.remove(t)
+	}
+
+	s.mutex.Lock()
+	delete(s.active, t)
+	s.mutex.Unlock()
+
+	s.dispatch()
+}
@@ -0,0 +1,185 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const sleepRecordDuration = 50 * time.Millisecond
+
+// sleepProcessor takes a fixed amount of time for every record, which it outputs unchanged.
+type sleepProcessor struct{}
+
+func (sleepProcessor) Init(context.Context, string) error { return nil }
+
+func (sleepProcessor) Process(ctx context.Context, record []string) ([]string, error) {
+	select {
+	case <-time.After(sleepRecordDuration):
+		return record, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (sleepProcessor) Flush() error { return nil }
+
+func (sleepProcessor) Close() error { return nil }
+
+// stubProcessor outputs the header and every record unchanged, records being
+// prefixed with "dry-run" in dry runs. Records whose first field is "fail"
+// fail processing.
+type stubProcessor struct{}
+
+func (stubProcessor) Init(context.Context, string) error { return nil }
+
+func (stubProcessor) Header(_ context.Context, header Header) ([]string, error) {
+	return header, nil
+}
+
+func (stubProcessor) Process(ctx context.Context, record []string) ([]string, error) {
+	if record[0] == "fail" {
+		return nil, errors.New("failed")
+	}
+	if IsDryRun(ctx) {
+		return append([]string{"dry-run"}, record...), nil
+	}
+	return record, nil
+}
+
+func (stubProcessor) Flush() error { return nil }
+
+func (stubProcessor) Close() error { return nil }
+
+func init() {
+	RegisterProcessor("test-sleep", func() Processor { return sleepProcessor{} })
+	RegisterProcessor("test-stub", func() Processor { return stubProcessor{} })
+}
+
+// testTaskOptions are the file and config of a task created by newTestTask.
+type testTaskOptions struct {
+	data string
+	cfg  Config
+}
+
+// testTaskOption changes the options of a task created by newTestTask.
+type testTaskOption func(*testTaskOptions)
+
+// withData sets the content of the file of the task.
+func withData(data string) testTaskOption {
+	return func(o *testTaskOptions) { o.data = data }
+}
+
+// withConfig sets the config of the task, test-sleep being the processor
+// unless another one is given.
+func withConfig(cfg Config) testTaskOption {
+	return func(o *testTaskOptions) {
+		if cfg.Processor == "" {
+			cfg.Processor = o.cfg.Processor
+		}
+		o.cfg = cfg
+	}
+}
+
+// newTestTask returns a task processing a file of three records with a
+// header, using test-sleep, unless the options say otherwise.
+func newTestTask(id string, t *testing.T, opts ...testTaskOption) *Task {
+	o := testTaskOptions{data: "id,name\n1,x\n2,y\n3,z\n", cfg: Config{Processor: "test-sleep"}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	path := filepath.Join(t.TempDir(), id+".csv")
+	if err := ioutil.WriteFile(path, []byte(o.data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tk, err := NewTask(id, path, o.cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tk
+}
+
+func waitStatus(tk *Task, status Status, t *testing.T) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		tk.mutex.Lock()
+		state := tk.State
+		tk.mutex.Unlock()
+
+		if state == status && !status.final() {
+			return
+		}
+		if state == status {
+			// Let the worker finish writing its files.
+			select {
+			case <-tk.done:
+				return
+			case <-time.After(time.Until(deadline)):
+				t.Fatalf("[%s] timed out waiting for the worker to stop", tk.ID)
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("[%s] timed out waiting for status %s", tk.ID, status)
+}
+
+func TestSchedulerLimit(t *testing.T) {
+	s := NewScheduler(1, false)
+	a, b := newTestTask("a", t), newTestTask("b", t)
+
+	s.Submit(a, Cause{})
+	s.Submit(b, Cause{})
+
+	waitStatus(a, TaskRunning, t)
+	waitStatus(b, TaskQueued, t)
+
+	// Paused tasks keep their slot.
+	a.Pause(Cause{})
+	time.Sleep(2 * sleepRecordDuration)
+	waitStatus(b, TaskQueued, t)
+	a.Resume(Cause{})
+
+	waitStatus(a, TaskFinished, t)
+	waitStatus(b, TaskRunning, t)
+	waitStatus(b, TaskFinished, t)
+}
+
+func TestSchedulerReleasePaused(t *testing.T) {
+	s := NewScheduler(1, true)
+	a, b := newTestTask("a", t), newTestTask("b", t)
+
+	s.Submit(a, Cause{})
+	s.Submit(b, Cause{})
+
+	waitStatus(a, TaskRunning, t)
+	a.Pause(Cause{})
+	waitStatus(b, TaskRunning, t)
+
+	a.Resume(Cause{})
+	waitStatus(a, TaskQueued, t)
+
+	waitStatus(b, TaskFinished, t)
+	waitStatus(a, TaskRunning, t)
+	waitStatus(a, TaskFinished, t)
+}
+
+func TestSchedulerTerminateQueued(t *testing.T) {
+	s := NewScheduler(1, false)
+	a, b := newTestTask("a", t), newTestTask("b", t)
+
+	s.Submit(a, Cause{})
+	s.Submit(b, Cause{})
+
+	waitStatus(b, TaskQueued, t)
+	b.Terminate(Cause{})
+	waitStatus(b, TaskTerminated, t)
+
+	waitStatus(a, TaskFinished, t)
+}
@@ -0,0 +1,74 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidSelector is returned when a label selector can't be parsed.
+var ErrInvalidSelector = errors.New("invalid label selector")
+
+// Requirement is a condition on a label of a task.
+type Requirement struct {
+	Key   string
+	Value string
+	// Not makes the label match when it is missing or has another value.
+	Not bool
+}
+
+func (r Requirement) String() string {
+	if r.Not {
+		return r.Key + "!=" + r.Value
+	}
+	return r.Key + "=" + r.Value
+}
+
+// Selector matches tasks whose labels meet all of its requirements.
+// The empty selector matches every task.
+type Selector []Requirement
+
+// ParseSelector parses a comma separated list of key=value and key!=value
+// requirements, e.g. team=billing,env!=prod.
+func ParseSelector(s string) (Selector, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	var sel Selector
+	for _, part := range strings.Split(s, ",") {
+		var r Requirement
+		kv := strings.SplitN(part, "!=", 2)
+		if len(kv) == 2 {
+			r.Not = true
+		} else if kv = strings.SplitN(part, "=", 2); len(kv) != 2 {
+			return nil, fmt.Errorf("%w: %q, expected key=value or key!=value", ErrInvalidSelector, part)
+		}
+
+		r.Key, r.Value = strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])
+		if err := ValidateLabels(map[string]string{r.Key: r.Value}); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidSelector, err)
+		}
+		sel = append(sel, r)
+	}
+	return sel, nil
+}
+
+// Matches reports whether the labels meet all the requirements of the selector.
+func (s Selector) Matches(labels map[string]string) bool {
+	for _, r := range s {
+		v, ok := labels[r.Key]
+		if (ok && v == r.Value) == r.Not {
+			return false
+		}
+	}
+	return true
+}
+
+func (s Selector) String() string {
+	parts := make([]string, len(s))
+	for i, r := range s {
+		parts[i] = r.String()
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,43 @@
+package task
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSelector(t *testing.T) {
+	labels := map[string]string{"team": "billing", "env": "staging"}
+
+	tests := []struct {
+		selector string
+		matches  bool
+	}{
+		{"", true},
+		{"team=billing", true},
+		{"team=billing,env!=prod", true},
+		{" team = billing , env != staging ", false},
+		{"team=search", false},
+		{"tier!=1", true},
+		{"tier=", false},
+	}
+
+	for _, tc := range tests {
+		sel, err := ParseSelector(tc.selector)
+		if err != nil {
+			t.Fatalf("%q: %v", tc.selector, err)
+		}
+		if got := sel.Matches(labels); got != tc.matches {
+			t.Errorf("%q: expected %v, got: %v", tc.selector, tc.matches, got)
+		}
+	}
+
+	if sel, _ := ParseSelector("team=billing,env!=prod"); sel.String() != "team=billing,env!=prod" {
+		t.Fatalf("incorrect string: %s", sel)
+	}
+
+	for _, s := range []string{"team", "team=a,", "=billing", "team=a b", "env!=a=b"} {
+		if _, err := ParseSelector(s); !errors.Is(err, ErrInvalidSelector) {
+			t.Errorf("%q: expected invalid selector, got: %v", s, err)
+		}
+	}
+}
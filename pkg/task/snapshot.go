@@ -0,0 +1,43 @@
+package task
+
+import "time"
+
+// Snapshot is a consistent view of a task at a point in time.
+type Snapshot struct {
+	ID        string   `json:"id"`
+	Status    Status   `json:"status"`
+	Actions   []Action `json:"actions"`
+	Config    Config   `json:"config"`
+	Metadata  Metadata `json:"metadata"`
+	Progress  Progress `json:"progress"`
+	Timeline  Timeline `json:"timeline"`
+	Error     *Error   `json:"error"`
+	RowErrors []Error  `json:"rowErrors"`
+	// Breakpoints are the current breakpoints of the task, see SetBreakpoints.
+	Breakpoints []Breakpoint `json:"breakpoints,omitempty"`
+}
+
+// Snapshot returns the current state of the task, all read at once.
+func (t *Task) Snapshot() Snapshot {
+	t.mutex.Lock()
+	s := Snapshot{
+		ID:        t.ID,
+		Status:    t.State,
+		Actions:   t.State.Actions(),
+		Config:    t.Config,
+		Metadata:  t.metadata.clone(),
+		Progress:  t.progress(),
+		RowErrors: append([]Error{}, t.rowErrors...),
+
+		Breakpoints: append([]Breakpoint(nil), t.breakpoints...),
+	}
+	if t.Err != nil {
+		s.Error = taskError(t.Err)
+	}
+	events := append([]Event(nil), t.events...)
+	t.mutex.Unlock()
+
+	s.Timeline = timeline(events, time.Now())
+	s.Metadata = s.Metadata.withTimes(s.Timeline)
+	return s
+}
@@ -0,0 +1,100 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Action is an operation requested on a task, moving it to another status.
+type Action string
+
+// Various possible actions on a task.
+const (
+	ActionStart     Action = "start"
+	ActionPause     Action = "pause"
+	ActionResume    Action = "resume"
+	ActionStep      Action = "step"
+	ActionTerminate Action = "terminate"
+)
+
+// actionOrder is the order in which allowed actions are listed.
+var actionOrder = []Action{ActionStart, ActionPause, ActionResume, ActionStep, ActionTerminate}
+
+// actions lists the statuses from which each action is allowed.
+var actions = map[Action][]Status{
+	ActionStart:     {TaskNotStarted},
+	ActionPause:     {TaskRunning},
+	ActionResume:    {TaskPausing, TaskPaused},
+	ActionStep:      {TaskPaused},
+	ActionTerminate: {TaskQueued, TaskRunning, TaskPausing, TaskPaused},
+}
+
+// transitions lists the statuses a task can move to from each status,
+// whether requested by an action or done by the worker itself.
+var transitions = map[Status][]Status{
+	TaskNotStarted:  {TaskQueued, TaskRunning},
+	TaskQueued:      {TaskRunning, TaskTerminating, TaskTerminated, TaskTimedOut},
+	TaskRunning:     {TaskPausing, TaskTerminating, TaskFinished, TaskGotError, TaskTimedOut},
+	TaskPausing:     {TaskPaused, TaskRunning, TaskTerminating, TaskFinished, TaskGotError, TaskTimedOut},
+	TaskPaused:      {TaskRunning, TaskQueued, TaskTerminating, TaskGotError, TaskTimedOut},
+	TaskTerminating: {TaskTerminated, TaskFinished, TaskGotError, TaskTimedOut},
+}
+
+// ErrInvalidTransition is returned when an action or status change isn't allowed
+// in the current status of a task. Use errors.Is to check for it.
+var ErrInvalidTransition = errors.New("invalid transition")
+
+// TransitionError describes an action which isn't allowed in the current status of a task.
+type TransitionError struct {
+	Action Action
+	Status Status
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("%s: cannot %s a task which is %s", ErrInvalidTransition, e.Action, e.Status)
+}
+
+// Is makes errors.Is(err, ErrInvalidTransition) match every TransitionError.
+func (e *TransitionError) Is(target error) bool {
+	return target == ErrInvalidTransition
+}
+
+// Allows reports whether the action is allowed on a task in this status.
+func (s Status) Allows(action Action) bool {
+	return contains(actions[action], s)
+}
+
+// CanTransition reports whether a task in this status can move to the other one.
+func (s Status) CanTransition(to Status) bool {
+	return contains(transitions[s], to)
+}
+
+// Actions returns the actions which are allowed on a task in this status.
+func (s Status) Actions() []Action {
+	allowed := []Action{}
+	for _, a := range actionOrder {
+		if s.Allows(a) {
+			allowed = append(allowed, a)
+		}
+	}
+	return allowed
+}
+
+// final reports whether a task in this status is done for good.
+func (s Status) final() bool {
+	return len(transitions[s]) == 0
+}
+
+// transient reports whether a task in this status waits for its worker to apply a change.
+func (s Status) transient() bool {
+	return s == TaskPausing || s == TaskTerminating
+}
+
+func contains(statuses []Status, s Status) bool {
+	for _, st := range statuses {
+		if st == s {
+			return true
+		}
+	}
+	return false
+}
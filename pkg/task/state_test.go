@@ -0,0 +1,49 @@
+package task
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestInvalidTransition(t *testing.T) {
+	tk := newTestTask("invalid", t)
+
+	for name, op := range map[string]func(Cause) error{
+		"pause":     tk.Pause,
+		"resume":    tk.Resume,
+		"terminate": tk.Terminate,
+	} {
+		err := op(Cause{})
+		if !errors.Is(err, ErrInvalidTransition) {
+			t.Fatalf("%s: expected ErrInvalidTransition, got: %v", name, err)
+		}
+
+		var terr *TransitionError
+		if !errors.As(err, &terr) || terr.Status != TaskNotStarted || string(terr.Action) != name {
+			t.Fatalf("%s: incorrect transition error: %v", name, err)
+		}
+	}
+
+	if err := tk.Run(Cause{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tk.Run(Cause{}); !errors.Is(err, ErrInvalidTransition) {
+		t.Fatalf("expected ErrInvalidTransition on second run, got: %v", err)
+	}
+}
+
+func TestStatusActions(t *testing.T) {
+	for status, expected := range map[Status][]Action{
+		TaskNotStarted:  {ActionStart},
+		TaskRunning:     {ActionPause, ActionTerminate},
+		TaskPausing:     {ActionResume, ActionTerminate},
+		TaskPaused:      {ActionResume, ActionStep, ActionTerminate},
+		TaskTerminating: {},
+		TaskFinished:    {},
+	} {
+		if actions := status.Actions(); !reflect.DeepEqual(actions, expected) {
+			t.Fatalf("incorrect actions for %s. expected: %v; got: %v", status, expected, actions)
+		}
+	}
+}
@@ -1,10 +1,12 @@
 package task
 
 import (
+	"context"
 	"encoding/csv"
+	"errors"
+	"fmt"
 	"io"
 	"log"
-	"math/rand"
 	"os"
 	"sync"
 	"time"
@@ -15,151 +17,787 @@ type Status string
 
 // Various possible task status.
 const (
-	TaskNotStarted Status = "not-started"
-	TaskRunning    Status = "running"
-	TaskPaused     Status = "paused"
-	TaskTerminated Status = "terminated"
-	TaskGotError   Status = "got-error"
-	TaskFinished   Status = "finished"
+	TaskNotStarted  Status = "not-started"
+	TaskQueued      Status = "queued"
+	TaskRunning     Status = "running"
+	TaskPausing     Status = "pausing"
+	TaskPaused      Status = "paused"
+	TaskTerminating Status = "terminating"
+	TaskTerminated  Status = "terminated"
+	TaskTimedOut    Status = "timed-out"
+	TaskGotError    Status = "got-error"
+	TaskFinished    Status = "finished"
 )
 
+// Config holds the user supplied configuration of a task.
+type Config struct {
+	// Processor is the name of the registered processor which handles the records.
+	Processor string `json:"processor"`
+	// CheckpointInterval is how often the progress is persisted while running.
+	// Checkpoints are still taken on pause and when the task stops if it is zero.
+	CheckpointInterval time.Duration `json:"checkpointInterval"`
+	// Timeout is how long the task may take from the moment it first starts running.
+	Timeout time.Duration `json:"timeout"`
+	// Deadline is the time by which the task must be done.
+	Deadline time.Time `json:"deadline"`
+	// StartAt is the time at which the task gets submitted, if it wasn't
+	// started before. Zero means the task is started by its creator.
+	StartAt time.Time `json:"startAt"`
+	// Output is the format of the file the processed records are written to.
+	Output string `json:"output"`
+	// Dialect is the format of the uploaded CSV file.
+	Dialect Dialect `json:"dialect"`
+	// Malformed is what to do with records which can't be parsed, MalformedFail if empty.
+	Malformed MalformedPolicy `json:"malformed"`
+	// ErrorBudget is the percentage of the records which may be skipped or
+	// quarantined before the task gets paused, zero means no budget.
+	ErrorBudget float64 `json:"errorBudget"`
+	// Retry tells how records failing with a retryable error are processed again.
+	Retry RetryPolicy `json:"retry"`
+	// Range restricts the task to part of the file, the whole file if zero.
+	Range Range `json:"range"`
+	// Breakpoints pause the task before it processes the matching records.
+	// They can be changed afterwards, see SetBreakpoints.
+	Breakpoints []Breakpoint `json:"breakpoints,omitempty"`
+}
+
 // Task represents a processing task in our system.
 type Task struct {
 	ID       string
 	FilePath string
+	Config   Config
 	State    Status
 	Err      error
 
-	pause     chan struct{}
-	resume    chan struct{}
-	terminate chan struct{}
-	mutex     sync.Mutex
+	processor      Processor
+	record         int64
+	offset         int64
+	lastCheckpoint time.Time
+	restoredPaused bool
+	started        bool
+	sched          *Scheduler
+	deadline       time.Time
+	events         []Event
+	out            *output
+	outputOffset   int64
+	quarantine     *output
+	budgetExceeded bool
+
+	quarantineOffset int64
+	rangeStart       int64
+	breakpoints      []Breakpoint
+	breakRow         int64
+	stepUntil        int64
+	saved            *Checkpoint
+	onChange         func(*Task)
+	metadata         Metadata
+
+	processed   int64
+	failed      int64
+	skipped     int64
+	quarantined int64
+	retries     int64
+	rowErrors   []Error
+	size        int64
+	total       int64
+	totalExact  bool
+	activeTime  time.Duration
+	activeSince time.Time
+
+	ctx     context.Context
+	cancel  context.CancelFunc
+	resume  chan struct{}
+	settled chan struct{}
+	done    chan struct{}
+	mutex   sync.Mutex
+
+	// pauseRequested wakes up the worker while it waits to retry a record.
+	pauseRequested chan struct{}
+	// saving orders the writes of the checkpoint file.
+	saving sync.Mutex
 }
 
 // NewTask returns an initialized instance of task.
-func NewTask(id, path string) *Task {
+// The default processor and output format are used if the config doesn't name them.
+func NewTask(id, path string, cfg Config) (*Task, error) {
+	if cfg.Processor == "" {
+		cfg.Processor = DefaultProcessor
+	}
+
+	switch cfg.Output {
+	case "":
+		cfg.Output = DefaultOutput
+	case OutputCSV, OutputJSONL:
+	default:
+		return nil, ErrUnknownOutput
+	}
+
+	if err := cfg.Dialect.validate(); err != nil {
+		return nil, err
+	}
+
+	switch cfg.Malformed {
+	case "":
+		cfg.Malformed = MalformedFail
+	case MalformedFail, MalformedSkip, MalformedQuarantine:
+	default:
+		return nil, ErrUnknownPolicy
+	}
+
+	if cfg.ErrorBudget < 0 || cfg.ErrorBudget > 100 {
+		return nil, ErrInvalidBudget
+	}
+
+	if err := cfg.Retry.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Range.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := ValidateBreakpoints(cfg.Breakpoints); err != nil {
+		return nil, err
+	}
+
+	p, err := NewProcessor(cfg.Processor)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	settled := make(chan struct{})
+	close(settled)
+
 	return &Task{
 		ID:        id,
 		FilePath:  path,
+		Config:    cfg,
 		State:     TaskNotStarted,
-		pause:     make(chan struct{}),
-		resume:    make(chan struct{}),
-		terminate: make(chan struct{}),
-	}
+		processor: p,
+		events:    []Event{{To: TaskNotStarted, Time: time.Now(), Cause: systemCause("created")}},
+		ctx:       ctx,
+		cancel:    cancel,
+		resume:    make(chan struct{}, 1),
+		settled:   settled,
+		done:      make(chan struct{}),
+
+		pauseRequested: make(chan struct{}, 1),
+		breakpoints:    append([]Breakpoint(nil), cfg.Breakpoints...),
+	}, nil
 }
 
-// Run is used to start the task.
-// No effect if task is not in not started status.
-func (t *Task) Run() {
-	if t.State != TaskNotStarted {
-		return
+// Run is used to start the task right away, bypassing any scheduler.
+// It returns a TransitionError if the task is not in not started status.
+func (t *Task) Run(cause Cause) error {
+	t.mutex.Lock()
+	err := t.check(ActionStart)
+	t.mutex.Unlock()
+
+	if err != nil {
+		return err
 	}
 
-	t.update(TaskRunning)
-	go t.process()
-	log.Printf("[%s] running\n", t.ID)
+	t.start(cause)
+	return nil
 }
 
-// Pause function pauses a running task.
-// If task is not running it doesn't have any effect.
-func (t *Task) Pause() {
-	if t.State != TaskRunning {
+// start moves the task to running, either launching its worker or waking up the paused one.
+func (t *Task) start(cause Cause) {
+	t.mutex.Lock()
+	status, started := t.State, t.started
+
+	switch {
+	case status == TaskTerminating && !started:
+		// Terminated while being picked from the queue, there is no worker to stop.
+		t.mutex.Unlock()
+		t.stop(TaskTerminated, "terminated before starting")
+		t.cleanup()
+		return
+	case status.final():
+		// The task stopped while queued, let the scheduler have its slot back.
+		t.mutex.Unlock()
+		t.notify(status)
+		return
+	case status != TaskNotStarted && status != TaskQueued && status != TaskPaused:
+		t.mutex.Unlock()
 		return
 	}
 
-	t.update(TaskPaused)
-	t.pause <- struct{}{}
-	log.Printf("[%s] paused\n", t.ID)
-}
+	t.started = true
+	t.setState(TaskRunning, cause)
+	t.mutex.Unlock()
+	t.notify(TaskRunning)
 
-// Resume function resumes a paused task.
-// If task is not paused it doesn't have any effect.
-func (t *Task) Resume() {
-	if t.State != TaskPaused {
+	if !started {
+		go t.process()
+		log.Printf("[%s] running\n", t.ID)
 		return
 	}
 
-	t.update(TaskRunning)
-	t.resume <- struct{}{}
+	// The worker picks this up whenever it gets to wait for it.
+	select {
+	case t.resume <- struct{}{}:
+	default:
+	}
 	log.Printf("[%s] resumed\n", t.ID)
 }
 
-// Terminate will kill the running/paused task.
-// Doesn't have any effect on already finished/terminated tasks.
-func (t *Task) Terminate() {
-	if !(t.State == TaskRunning || t.State == TaskPaused) {
-		return
+// Pause function asks a running task to pause once the record being processed is done.
+// The task is pausing until its worker applies it, see Settled.
+// It returns a TransitionError if the task is not running.
+func (t *Task) Pause(cause Cause) error {
+	t.mutex.Lock()
+	if err := t.check(ActionPause); err != nil {
+		t.mutex.Unlock()
+		return err
+	}
+	t.setState(TaskPausing, cause)
+	t.mutex.Unlock()
+
+	select {
+	case t.pauseRequested <- struct{}{}:
+	default:
+	}
+
+	t.notify(TaskPausing)
+	log.Printf("[%s] pausing\n", t.ID)
+	return nil
+}
+
+// Resume function resumes a paused task, or cancels the pause of a pausing one.
+// If the task released its slot in the scheduler, it is queued until a slot is free.
+// It returns a TransitionError if the task is neither paused nor pausing.
+func (t *Task) Resume(cause Cause) error {
+	return t.proceed(ActionResume, cause)
+}
+
+// Step resumes a paused task to process a single record, after which it
+// pauses again. It returns a TransitionError if the task is not paused.
+func (t *Task) Step(cause Cause) error {
+	return t.proceed(ActionStep, cause)
+}
+
+// proceed resumes the task for the action, see Resume and Step.
+func (t *Task) proceed(action Action, cause Cause) error {
+	t.mutex.Lock()
+	if err := t.check(action); err != nil {
+		t.mutex.Unlock()
+		return err
+	}
+
+	t.stepUntil = 0
+	if action == ActionStep {
+		t.stepUntil = t.record + 1
+	}
+
+	switch {
+	case t.State == TaskPausing:
+		t.setState(TaskRunning, cause)
+		t.mutex.Unlock()
+
+		t.notify(TaskRunning)
+		log.Printf("[%s] pause cancelled\n", t.ID)
+		return nil
+	case t.sched != nil && !t.sched.holds(t):
+		t.setState(TaskQueued, cause)
+		t.mutex.Unlock()
+
+		t.notify(TaskQueued)
+		t.sched.enqueue(t)
+		return nil
+	}
+	t.mutex.Unlock()
+
+	t.start(cause)
+	return nil
+}
+
+// Terminate asks the running/paused/queued task to stop, interrupting the record being processed.
+// The task is terminating until its worker stops, see Settled.
+// It returns a TransitionError if the task already stopped or never started.
+func (t *Task) Terminate(cause Cause) error {
+	t.mutex.Lock()
+	if err := t.check(ActionTerminate); err != nil {
+		t.mutex.Unlock()
+		return err
+	}
+	status, started := t.State, t.started
+	t.setState(TaskTerminating, cause)
+	t.mutex.Unlock()
+
+	t.notify(TaskTerminating)
+	log.Printf("[%s] terminating\n", t.ID)
+
+	// A queued task which never started has no worker to stop.
+	if status == TaskQueued && !started && t.sched != nil && t.sched.remove(t) {
+		t.stop(TaskTerminated, "terminated before starting")
+		t.cleanup()
+		return nil
 	}
 
-	t.terminate <- struct{}{}
+	t.cancel()
+	return nil
+}
+
+// Actions returns the actions which are currently allowed on the task.
+func (t *Task) Actions() []Action {
+	return t.Status().Actions()
+}
+
+// Files returns the paths of the files kept for the task: the uploaded file,
+// its checkpoint, output and quarantine. Some of them may not exist.
+func (t *Task) Files() []string {
+	return []string{t.FilePath, t.FilePath + CheckpointExt, t.OutputPath(), t.QuarantinePath(), t.IndexPath()}
+}
+
+// Status returns the current status of the task.
+func (t *Task) Status() Status {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.State
+}
+
+// Settled returns a channel which is closed once the task is no longer
+// pausing or terminating, i.e. its worker applied the requested change.
+func (t *Task) Settled() <-chan struct{} {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.settled
 }
 
-func (t *Task) finish() {
-	t.update(TaskFinished)
-	t.cleanup()
-	log.Printf("[%s] finished\n", t.ID)
+// Done returns a channel which is closed once the task stopped for good and
+// its worker is done with its files. It stays open if the task never starts.
+func (t *Task) Done() <-chan struct{} {
+	return t.done
 }
 
-func (t *Task) kill() {
-	t.update(TaskTerminated)
-	t.cleanup()
-	log.Printf("[%s] terminated\n", t.ID)
+// stop moves the task to a final status.
+func (t *Task) stop(status Status, reason string) {
+	t.update(status, systemCause(reason))
+	t.checkpoint()
+	log.Printf("[%s] %s\n", t.ID, status)
 }
 
+// error stops the task with the got-error status, keeping err as an *Error.
 func (t *Task) error(err error) {
-	t.update(TaskGotError)
-	t.Err = err
+	t.mutex.Lock()
+	if serr := t.setState(TaskGotError, systemCause(err.Error())); serr != nil {
+		log.Printf("[%s] %v\n", t.ID, serr)
+	}
+	t.Err = taskError(err)
+	t.mutex.Unlock()
+
+	t.notify(TaskGotError)
+	t.checkpoint()
+	log.Printf("[%s] got error: %v\n", t.ID, err)
 }
 
 func (t *Task) process() {
-	file, err := os.Open(t.FilePath)
+	defer t.cleanup()
+
+	ctx := t.ctx
+	if deadline := t.runDeadline(); !deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, deadline)
+		defer cancel()
+	}
+
+	if err := t.processor.Init(ctx, t.ID); err != nil {
+		t.error(err)
+		return
+	}
+
+	status, err := t.processRecords(ctx)
+	if cerr := t.processor.Close(); err == nil {
+		err = cerr
+	}
+
 	if err != nil {
 		t.error(err)
 		return
 	}
+	t.stop(status, stopReasons[status])
+}
+
+// stopReasons explains the final statuses the worker stops with.
+var stopReasons = map[Status]string{
+	TaskFinished:   "all records processed",
+	TaskTerminated: "terminated",
+	TaskTimedOut:   "deadline exceeded",
+}
+
+// runDeadline returns the time by which the task must be done, the timeout
+// being counted from when it first started. The zero time means no deadline.
+func (t *Task) runDeadline() time.Time {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.deadline.IsZero() {
+		t.deadline = t.Config.Deadline
+		if t.Config.Timeout > 0 {
+			d := time.Now().Add(t.Config.Timeout)
+			if t.deadline.IsZero() || d.Before(t.deadline) {
+				t.deadline = d
+			}
+		}
+	}
+	return t.deadline
+}
+
+// processRecords hands every record of the file to the processor and writes
+// the records it returns to the output file. It returns the final status of the task unless processing failed.
+func (t *Task) processRecords(ctx context.Context) (Status, error) {
+	file, err := os.Open(t.FilePath)
+	if err != nil {
+		return "", err
+	}
 	defer file.Close()
 
-	csvR := csv.NewReader(file)
+	if err := t.measure(file); err != nil {
+		return "", err
+	}
+
+	// The header is read again whenever the task starts, but records are
+	// only counted from right after it.
+	var header Header
+	fresh := t.offset == 0
+	if t.Config.Dialect.Header {
+		var end int64
+		header, end, err = t.readHeader(file)
+		if err != nil {
+			return "", err
+		}
+		if fresh {
+			t.mutex.Lock()
+			t.offset = end
+			t.mutex.Unlock()
+		}
+	}
+
+	// Tasks restricted to a range skip to its first record.
+	if fresh && t.Config.Range != (Range{}) {
+		start, err := t.seekRange(file, t.offset, header)
+		if err != nil {
+			return "", err
+		}
+		t.mutex.Lock()
+		t.offset, t.rangeStart = start, start
+		t.mutex.Unlock()
+	}
+
+	// Continue from the last checkpoint, if any.
+	records, err := t.Config.Dialect.records(file, t.offset, header)
+	if err != nil {
+		return "", err
+	}
+
+	t.out, err = openOutput(t.OutputPath(), t.Config.Output, t.outputOffset)
+	if err != nil {
+		return "", err
+	}
+	if t.Config.Malformed == MalformedQuarantine {
+		if err := t.openQuarantine(); err != nil {
+			t.out.close()
+			return "", err
+		}
+	}
+	defer func() {
+		if err := t.closeOutput(); err != nil {
+			log.Printf("[%s] closing output: %v\n", t.ID, err)
+		}
+	}()
+
+	if hp, ok := t.processor.(HeaderProcessor); ok && header != nil {
+		result, err := hp.Header(ctx, header)
+		if err != nil {
+			return "", err
+		}
+		if fresh && result != nil {
+			if err := t.out.write(result); err != nil {
+				return "", err
+			}
+			if err := t.flushOutput(); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	t.checkpoint()
+
+	// A task restored in paused state waits to be resumed before reading anything.
+	if t.restoredPaused {
+		if !t.waitResume(ctx) {
+			return stopped(ctx), nil
+		}
+	}
+
+	t.startClock()
+	defer t.stopClock()
 
-Out:
 	for {
 		select {
-		case <-t.terminate:
-			t.kill()
-			return
-		case <-t.pause:
-			select {
-			case <-t.resume:
-			case <-t.terminate:
-				t.kill()
-				return
-			}
+		case <-ctx.Done():
+			return stopped(ctx), nil
 		default:
-			record, err := csvR.Read()
-			if err == io.EOF {
-				break Out
+		}
+
+		t.checkStep()
+		if t.Status() == TaskPausing {
+			resumed, err := t.applyPause(ctx, "pause applied")
+			if err != nil {
+				return "", err
+			}
+			if !resumed {
+				return stopped(ctx), nil
+			}
+			continue
+		}
+
+		start := t.offset
+		if t.Config.Range.ended(t.row()+1, start) {
+			return t.finish()
+		}
+
+		record, end, err := records.read()
+		if err == io.EOF {
+			return t.finish()
+		}
+
+		var perr *csv.ParseError
+		if errors.As(err, &perr) {
+			if err := t.malformed(file, start, end, perr); err != nil {
+				return "", err
+			}
+			if err := t.checkpointIfDue(); err != nil {
+				return "", err
+			}
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+
+		// The record is processed once the task is resumed from a breakpoint.
+		if t.breakpoint(record, header) {
+			resumed, err := t.applyPause(ctx, "breakpoint")
+			if err != nil {
+				return "", err
+			}
+			if !resumed {
+				return stopped(ctx), nil
+			}
+		}
+
+		result, err := t.processWithRetry(ctx, record)
+		var aerr *abortError
+		if errors.As(err, &aerr) {
+			return "", aerr.err
+		}
+		if err != nil && ctx.Err() != nil {
+			// The record was interrupted, so it doesn't count as processed.
+			return stopped(ctx), nil
+		}
+		if err == nil && result != nil {
+			if werr := t.out.write(result); werr != nil {
+				return "", werr
+			}
+		}
+
+		if err != nil {
+			rerr := t.recordError(file, start, end, 0, err)
+			quarantine := t.Config.Malformed == MalformedQuarantine
+			if quarantine {
+				if err := t.quarantineRecord(rerr); err != nil {
+					return "", err
+				}
+			}
+
+			t.mutex.Lock()
+			t.record++
+			t.offset = end
+			t.failed++
+			if quarantine {
+				t.quarantined++
+				t.addRowError(rerr)
+			}
+			t.mutex.Unlock()
+
+			if !quarantine {
+				return "", rerr
+			}
+
+			log.Printf("[%s] quarantined %v\n", t.ID, rerr)
+			t.checkErrorBudget()
+			if err := t.checkpointIfDue(); err != nil {
+				return "", err
 			}
-			processRecord(record)
-			log.Printf("[%s] processed: %v\n", t.ID, record)
+			continue
 		}
+
+		t.mutex.Lock()
+		t.record++
+		t.offset = end
+		t.processed++
+		t.mutex.Unlock()
+
+		log.Printf("[%s] processed: %v\n", t.ID, record)
+		if err := t.checkpointIfDue(); err != nil {
+			return "", err
+		}
+	}
+}
+
+// finish flushes the processor and the output once all the records are processed.
+func (t *Task) finish() (Status, error) {
+	if err := t.processor.Flush(); err != nil {
+		return "", err
+	}
+	return TaskFinished, t.flushOutput()
+}
+
+// readHeader reads the first record of the file, returning it along with the
+// offset right after it. The header is nil if the file is empty.
+func (t *Task) readHeader(file *os.File) (Header, int64, error) {
+	records, err := t.Config.Dialect.records(file, 0, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	header, end, err := records.read()
+	if err == io.EOF {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	return header, end, nil
+}
+
+// applyPause flushes the processor and the output, and moves a pausing task to paused
+// for the reason, then waits for it to be resumed. It reports whether the task should go on.
+func (t *Task) applyPause(ctx context.Context, reason string) (bool, error) {
+	t.stopClock()
+	if err := t.processor.Flush(); err != nil {
+		return false, err
+	}
+	if err := t.flushOutput(); err != nil {
+		return false, err
+	}
+
+	// The pause might have been cancelled or the task terminated meanwhile.
+	if !t.transition(TaskPaused, systemCause(reason), TaskPausing) {
+		t.startClock()
+		return true, nil
+	}
+	log.Printf("[%s] paused\n", t.ID)
+	t.checkpoint()
+
+	return t.waitResume(ctx), nil
+}
+
+// waitResume blocks a paused task until it is either resumed or its context is done.
+// It reports whether the task was resumed.
+func (t *Task) waitResume(ctx context.Context) bool {
+	select {
+	case <-t.resume:
+		t.startClock()
+		t.checkpoint()
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// stopped returns the final status of a task whose context is done.
+func stopped(ctx context.Context) Status {
+	if ctx.Err() == context.DeadlineExceeded {
+		return TaskTimedOut
 	}
+	return TaskTerminated
+}
+
+func (t *Task) update(status Status, cause Cause) {
+	t.mutex.Lock()
+	err := t.setState(status, cause)
+	t.mutex.Unlock()
 
-	t.finish()
+	if err != nil {
+		log.Printf("[%s] %v\n", t.ID, err)
+		return
+	}
+	t.notify(status)
 }
 
-func processRecord(record []string) {
-	r := rand.Intn(1000)
-	time.Sleep(time.Duration(r) * time.Millisecond)
+// check returns a TransitionError unless the action is allowed in the current status.
+// The caller must hold the mutex.
+func (t *Task) check(action Action) error {
+	if !t.State.Allows(action) {
+		return &TransitionError{Action: action, Status: t.State}
+	}
+	return nil
 }
 
-func (t *Task) update(status Status) {
+// transition moves the task to the status only if it currently is in one of the given ones.
+// It reports whether the status was changed.
+func (t *Task) transition(to Status, cause Cause, from ...Status) bool {
 	t.mutex.Lock()
+	ok := contains(from, t.State) && t.setState(to, cause) == nil
+	t.mutex.Unlock()
+
+	if ok {
+		t.notify(to)
+	}
+	return ok
+}
+
+// setState changes the status if the transition is allowed, recording it in
+// the history and keeping track of whether the task settled.
+// The caller must hold the mutex.
+func (t *Task) setState(status Status, cause Cause) error {
+	if !t.State.CanTransition(status) {
+		return fmt.Errorf("%w from %s to %s", ErrInvalidTransition, t.State, status)
+	}
+	t.addEvent(t.State, status, cause)
+
+	was := t.State.transient()
 	t.State = status
+
+	switch now := status.transient(); {
+	case !was && now:
+		t.settled = make(chan struct{})
+	case was && !now:
+		close(t.settled)
+	}
+	return nil
+}
+
+// notify lets the scheduler and the OnChange function know about the new status of the task.
+func (t *Task) notify(status Status) {
+	if t.sched != nil {
+		t.sched.updated(t, status)
+	}
+
+	t.mutex.Lock()
+	onChange := t.onChange
+	t.mutex.Unlock()
+
+	if onChange != nil {
+		onChange(t)
+	}
+}
+
+// OnChange sets a function called whenever the status of the task changes or
+// it gets checkpointed, e.g. to persist its Record. The function must not
+// control the task.
+func (t *Task) OnChange(fn func(*Task)) {
+	t.mutex.Lock()
+	t.onChange = fn
 	t.mutex.Unlock()
 }
 
+// cleanup releases the context of the task and signals that it is done.
 func (t *Task) cleanup() {
-	close(t.pause)
-	close(t.resume)
-	close(t.terminate)
+	t.cancel()
+	close(t.done)
 }
@@ -0,0 +1,145 @@
+package task
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// blockingProcessor blocks on every record until its context is done.
+type blockingProcessor struct{}
+
+func (blockingProcessor) Init(context.Context, string) error { return nil }
+
+func (blockingProcessor) Process(ctx context.Context, _ []string) ([]string, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (blockingProcessor) Flush() error { return nil }
+
+func (blockingProcessor) Close() error { return nil }
+
+func TestTerminateInterruptsRecord(t *testing.T) {
+	tk := newTestTask("terminate", t)
+	tk.processor = blockingProcessor{}
+
+	tk.Run(Cause{})
+	time.Sleep(10 * time.Millisecond)
+
+	terminated := make(chan struct{})
+	go func() {
+		tk.Terminate(Cause{})
+		close(terminated)
+	}()
+
+	select {
+	case <-terminated:
+	case <-time.After(time.Second):
+		t.Fatal("terminate blocked on the record being processed")
+	}
+	waitStatus(tk, TaskTerminated, t)
+}
+
+func TestTimeoutWhilePaused(t *testing.T) {
+	tk := newTestTask("timeout", t)
+	tk.Config.Timeout = 5 * sleepRecordDuration
+
+	tk.Run(Cause{})
+	tk.Pause(Cause{})
+
+	waitStatus(tk, TaskTimedOut, t)
+}
+
+func TestDeadlinePassedBeforeStart(t *testing.T) {
+	tk := newTestTask("deadline", t)
+	tk.Config.Deadline = time.Now().Add(-time.Second)
+
+	tk.Run(Cause{})
+
+	waitStatus(tk, TaskTimedOut, t)
+	if tk.Progress().Processed != 0 {
+		t.Fatal("expected no records to be processed")
+	}
+}
+
+// nopProcessor does nothing, so tasks using it finish almost right away.
+type nopProcessor struct{}
+
+func (nopProcessor) Init(context.Context, string) error { return nil }
+
+func (nopProcessor) Process(context.Context, []string) ([]string, error) { return nil, nil }
+
+func (nopProcessor) Flush() error { return nil }
+
+func (nopProcessor) Close() error { return nil }
+
+func TestPauseDoesNotBlock(t *testing.T) {
+	tk := newTestTask("pause", t)
+	tk.processor = blockingProcessor{}
+
+	tk.Run(Cause{})
+	time.Sleep(10 * time.Millisecond)
+
+	paused := make(chan struct{})
+	go func() {
+		tk.Pause(Cause{})
+		close(paused)
+	}()
+
+	select {
+	case <-paused:
+	case <-time.After(time.Second):
+		t.Fatal("pause blocked on the record being processed")
+	}
+
+	if s := tk.Status(); s != TaskPausing {
+		t.Fatalf("incorrect status. expected: %s; got: %s", TaskPausing, s)
+	}
+	select {
+	case <-tk.Settled():
+		t.Fatal("task settled before the worker applied the pause")
+	default:
+	}
+
+	tk.Terminate(Cause{})
+	select {
+	case <-tk.Settled():
+	case <-time.After(time.Second):
+		t.Fatal("task didn't settle after terminating")
+	}
+	waitStatus(tk, TaskTerminated, t)
+}
+
+func TestConcurrentControl(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		tk := newTestTask("concurrent", t)
+		tk.processor = nopProcessor{}
+		s := NewScheduler(1, i%2 == 0)
+		s.Submit(tk, Cause{})
+
+		var wg sync.WaitGroup
+		for _, op := range []func(Cause) error{tk.Pause, tk.Resume, tk.Terminate, tk.Pause, tk.Resume} {
+			wg.Add(1)
+			go func(op func(Cause) error) {
+				defer wg.Done()
+				op(Cause{})
+			}(op)
+		}
+		wg.Wait()
+
+		// Whatever happened, the task must be able to stop.
+		tk.Resume(Cause{})
+		tk.Terminate(Cause{})
+		select {
+		case <-tk.done:
+		case <-time.After(time.Second):
+			t.Fatalf("task got stuck as %s", tk.Status())
+		}
+
+		if s := tk.Status(); !s.final() {
+			t.Fatalf("expected a final status, got: %s", s)
+		}
+	}
+}